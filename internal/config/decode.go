@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// format identifies the serialization of a config file.
+type format string
+
+const (
+	formatJSON format = "json"
+	formatYAML format = "yaml"
+	formatTOML format = "toml"
+)
+
+// formatFromPath picks a format from the file extension, defaulting to JSON.
+func formatFromPath(path string) format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return formatYAML
+	case ".toml":
+		return formatTOML
+	default:
+		return formatJSON
+	}
+}
+
+// toJSON converts a YAML or TOML document into equivalent JSON so every format
+// goes through the same schema validation and struct decoding.
+func toJSON(data []byte, f format) ([]byte, error) {
+	var doc any
+	switch f {
+	case formatJSON:
+		return data, nil
+	case formatYAML:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		if doc == nil {
+			doc = map[string]any{}
+		}
+	case formatTOML:
+		var table map[string]any
+		if err := toml.Unmarshal(data, &table); err != nil {
+			return nil, err
+		}
+		doc = table
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", f)
+	}
+
+	normalized, err := normalize(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(normalized)
+}
+
+// normalize rewrites map[any]any (produced by YAML for non-string keys)
+// into map[string]any so the document can be encoded as JSON.
+func normalize(v any) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			n, err := normalize(child)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = n
+		}
+		return v, nil
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, child := range v {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported non-string key %v", k)
+			}
+			n, err := normalize(child)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = n
+		}
+		return m, nil
+	case []any:
+		for i, child := range v {
+			n, err := normalize(child)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = n
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// configNames lists the standalone config files Discover looks for, in order
+// of preference.
+var configNames = []string{
+	"commit-types.json",
+	"commit-types.yaml",
+	"commit-types.yml",
+	"commit-types.toml",
+	".commit-types.json",
+}
+
+// hostSections maps files that can embed a config to the key holding it.
+var hostSections = map[string][]string{
+	"package.json":   {"commit-types"},
+	"pyproject.toml": {"tool", "commit-types"},
+}
+
+// hostNames is the order in which host files are checked.
+var hostNames = []string{"package.json", "pyproject.toml"}
+
+// Discover looks for a config in dir and then its parents, stopping after the
+// git root (the first directory containing .git). Standalone config files
+// win over a section embedded in package.json or pyproject.toml.
+func Discover(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("resolving directory: %w", err)
+	}
+	start := dir
+
+	for {
+		for _, name := range configNames {
+			path := filepath.Join(dir, name)
+			if fileExists(path) {
+				return path, nil
+			}
+		}
+		for _, name := range hostNames {
+			path := filepath.Join(dir, name)
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			if _, ok, err := extractSection(raw, formatFromPath(path), hostSections[name]); err != nil {
+				return "", fmt.Errorf("reading %s: %w", path, err)
+			} else if ok {
+				return path, nil
+			}
+		}
+
+		if fileExists(filepath.Join(dir, ".git")) {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return "", fmt.Errorf("no commit-types config found in %s or its parents (looked for %s, or a commit-types section in %s)",
+		start, strings.Join(configNames, ", "), strings.Join(hostNames, ", "))
+}
+
+// hostSection returns the key path of the config embedded in path, or nil if
+// path is a standalone config file.
+func hostSection(path string) []string {
+	return hostSections[filepath.Base(path)]
+}
+
+// extractSection returns the JSON encoding of the value at keys in raw, and
+// whether it was present.
+func extractSection(raw []byte, f format, keys []string) ([]byte, bool, error) {
+	data, err := toJSON(raw, f)
+	if err != nil {
+		return nil, false, err
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, false, err
+	}
+	for _, key := range keys {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil, false, nil
+		}
+		if value, ok = obj[key]; !ok {
+			return nil, false, nil
+		}
+	}
+	section, err := json.Marshal(value)
+	if err != nil {
+		return nil, false, err
+	}
+	return section, true, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
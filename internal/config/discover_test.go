@@ -0,0 +1,114 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiscoverWalksUp(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		".git/HEAD":          "ref: refs/heads/main\n",
+		"commit-types.yaml":  "types: {}\n",
+		"packages/web/x.txt": "",
+	})
+
+	path, err := Discover(filepath.Join(dir, "packages", "web"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != filepath.Join(dir, "commit-types.yaml") {
+		t.Errorf("expected the root config, got %s", path)
+	}
+}
+
+func TestDiscoverPreference(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		".git/HEAD":          "",
+		"package.json":       `{"commit-types": {"types": {}}}`,
+		".commit-types.json": `{"types": {}}`,
+		"commit-types.toml":  "[types]\n",
+	})
+
+	path, err := Discover(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filepath.Base(path) != "commit-types.toml" {
+		t.Errorf("expected commit-types.toml to win, got %s", path)
+	}
+}
+
+func TestDiscoverNearestWins(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		".git/HEAD":                    "",
+		"commit-types.json":            `{"types": {}}`,
+		"packages/api/pyproject.toml":  "[tool.commit-types]\nextends = \"../../commit-types.json\"\n",
+		"packages/web/package.json":    `{"name": "web"}`,
+		"packages/web/src/index.ts":    "",
+		"packages/api/src/__init__.py": "",
+	})
+
+	path, err := Discover(filepath.Join(dir, "packages", "api", "src"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != filepath.Join(dir, "packages", "api", "pyproject.toml") {
+		t.Errorf("expected pyproject.toml, got %s", path)
+	}
+
+	// package.json without a commit-types key is skipped.
+	path, err = Discover(filepath.Join(dir, "packages", "web", "src"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != filepath.Join(dir, "commit-types.json") {
+		t.Errorf("expected the root config, got %s", path)
+	}
+}
+
+func TestDiscoverStopsAtGitRoot(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"commit-types.json": `{"types": {}}`,
+		"repo/.git/HEAD":    "",
+		"repo/src/main.go":  "",
+	})
+
+	_, err := Discover(filepath.Join(dir, "repo", "src"))
+	if err == nil || !strings.Contains(err.Error(), "no commit-types config found") {
+		t.Errorf("expected not-found error, got %v", err)
+	}
+}
+
+func TestLoadEmbedded(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"package.json": `{
+  "name": "app",
+  "commit-types": {"types": {"feat": {"description": "A new feature", "changelog_group": "Added"}}}
+}`,
+		"pyproject.toml": `[project]
+name = "app"
+
+[tool.commit-types]
+extends = "package.json"
+
+[tool.commit-types.types.fix]
+description = "A bug fix"
+changelog_group = "Fixed"
+`,
+		"other/package.json": `{"name": "other"}`,
+	})
+
+	cfg, err := Load(filepath.Join(dir, "pyproject.toml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Types) != 2 {
+		t.Errorf("expected feat and fix, got %v", cfg.TypeNames())
+	}
+
+	_, err = Load(filepath.Join(dir, "other", "package.json"))
+	if err == nil || !strings.Contains(err.Error(), `no "commit-types" section`) {
+		t.Errorf("expected missing section error, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// loadDocument reads a config file or "preset:<name>", validates it against
+// the schema and resolves its "extends" chain. The result is the flattened
+// document in the generic JSON data model, with "extends" consumed and null
+// removals applied. stack holds the sources currently being resolved, to
+// detect cycles.
+func loadDocument(path string, stack []string) (map[string]any, error) {
+	key := path
+	if !strings.HasPrefix(path, presetPrefix) {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("resolving config path: %w", err)
+		}
+		key = abs
+	}
+	for _, p := range stack {
+		if p == key {
+			return nil, fmt.Errorf("extends cycle: %s -> %s", strings.Join(stack, " -> "), key)
+		}
+	}
+	stack = append(stack, key)
+
+	raw, err := readSource(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if keys := hostSection(path); keys != nil {
+		var ok bool
+		data, ok, err = extractSection(raw, formatFromPath(path), keys)
+		if err == nil && !ok {
+			err = fmt.Errorf("no %q section", strings.Join(keys, "."))
+		}
+	} else {
+		data, err = toJSON(raw, formatFromPath(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+
+	parsed, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parsing config file: %w", err)
+	}
+	if err := validateSchema(parsed); err != nil {
+		return nil, err
+	}
+	doc, ok := parsed.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("parsing config file: expected an object")
+	}
+
+	resolved := map[string]any{}
+	for _, ref := range extendsRefs(doc["extends"]) {
+		if !strings.HasPrefix(ref, presetPrefix) && !filepath.IsAbs(ref) {
+			ref = filepath.Join(filepath.Dir(path), ref)
+		}
+		base, err := loadDocument(ref, stack)
+		if err != nil {
+			var verr *ValidationError
+			if errors.As(err, &verr) && verr.File == "" {
+				verr.File = ref
+				return nil, verr
+			}
+			return nil, fmt.Errorf("extends %s: %w", ref, err)
+		}
+		mergeDocument(resolved, base)
+	}
+	mergeDocument(resolved, doc)
+
+	return resolved, nil
+}
+
+// readSource returns the contents of a config file or built-in preset.
+func readSource(path string) ([]byte, error) {
+	if name, ok := strings.CutPrefix(path, presetPrefix); ok {
+		return Preset(name)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	return raw, nil
+}
+
+// extendsRefs normalizes the "extends" value, which may be a single string or a list.
+func extendsRefs(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		refs := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				refs = append(refs, s)
+			}
+		}
+		return refs
+	default:
+		return nil
+	}
+}
+
+// mergeDocument applies over on top of base, in place:
+//
+//   - types, scopes, packages: merged per entry and per field; a null entry removes it
+//   - commitlint_rules: merged per rule; a null rule removes it
+//   - excluded_scopes: union of both lists; a "!scope" entry removes scope
+//   - breaking, dependencies: merged per field; null removes the section
+//   - generators: merged per generator; a null generator removes its overrides
+//   - anything else: replaced by over's value
+func mergeDocument(base, over map[string]any) {
+	for key, value := range over {
+		switch key {
+		case "extends":
+			continue
+		case "types", "scopes", "packages":
+			base[key] = mergeFields(asObject(base[key]), asObject(value))
+		case "commitlint_rules", "generators":
+			base[key] = mergeEntries(asObject(base[key]), asObject(value))
+		case "excluded_scopes":
+			base[key] = mergeScopes(base[key], value)
+		case "breaking", "dependencies":
+			if value == nil {
+				delete(base, key)
+				continue
+			}
+			base[key] = mergeEntries(asObject(base[key]), asObject(value))
+		default:
+			base[key] = value
+		}
+	}
+}
+
+func mergeFields(base, over map[string]any) map[string]any {
+	for name, value := range over {
+		overType, ok := value.(map[string]any)
+		if !ok {
+			delete(base, name)
+			continue
+		}
+		merged := map[string]any{}
+		for k, v := range asObject(base[name]) {
+			merged[k] = v
+		}
+		for k, v := range overType {
+			merged[k] = v
+		}
+		base[name] = merged
+	}
+	return base
+}
+
+func mergeEntries(base, over map[string]any) map[string]any {
+	for name, value := range over {
+		if value == nil {
+			delete(base, name)
+			continue
+		}
+		base[name] = value
+	}
+	return base
+}
+
+func mergeScopes(base, over any) []any {
+	var scopes []string
+	for _, list := range []any{base, over} {
+		items, _ := list.([]any)
+		for _, item := range items {
+			scope, _ := item.(string)
+			if removed, ok := strings.CutPrefix(scope, "!"); ok {
+				scopes = slices.DeleteFunc(scopes, func(s string) bool { return s == removed })
+			} else if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	result := make([]any, len(scopes))
+	for i, scope := range scopes {
+		result[i] = scope
+	}
+	return result
+}
+
+func asObject(v any) map[string]any {
+	if m, ok := v.(map[string]any); ok {
+		return m
+	}
+	return map[string]any{}
+}
@@ -0,0 +1,258 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("creating dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+const sharedConfig = `{
+  "description": "org-wide types",
+  "types": {
+    "feat": {"description": "A new feature", "changelog_group": "Added", "bump": "minor"},
+    "fix": {"description": "A bug fix", "changelog_group": "Fixed", "bump": "patch"},
+    "chore": {"description": "Chores"},
+    "data": {"description": "Data changes", "changelog_group": "Data"}
+  },
+  "excluded_scopes": ["release", "deps"],
+  "commitlint_rules": {
+    "body-max-line-length": [0, "always", 200],
+    "header-max-length": [2, "always", 100]
+  }
+}`
+
+func TestLoadExtends(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"shared/commit-types.json": sharedConfig,
+		"repo/commit-types.json": `{
+  "extends": ["../shared/commit-types.json"],
+  "types": {
+    "feat": {"bump": "major"},
+    "security": {"description": "Security fixes", "changelog_group": "Security", "bump": "patch"},
+    "data": null
+  },
+  "excluded_scopes": ["!deps", "ci"],
+  "commitlint_rules": {
+    "header-max-length": null
+  }
+}`,
+	})
+
+	cfg, err := Load(filepath.Join(dir, "repo", "commit-types.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Description != "org-wide types" {
+		t.Errorf("description should be inherited, got %q", cfg.Description)
+	}
+
+	feat := cfg.Types["feat"]
+	if feat.Bump != "major" {
+		t.Errorf("feat bump should be overridden to major, got %q", feat.Bump)
+	}
+	if feat.Description != "A new feature" || feat.ChangelogGroup == nil || *feat.ChangelogGroup != "Added" {
+		t.Errorf("feat should keep inherited fields, got %+v", feat)
+	}
+	if _, ok := cfg.Types["security"]; !ok {
+		t.Error("security should be added")
+	}
+	if _, ok := cfg.Types["data"]; ok {
+		t.Error("data should be removed by null")
+	}
+	if len(cfg.Types) != 4 {
+		t.Errorf("expected 4 types, got %d", len(cfg.Types))
+	}
+
+	if strings.Join(cfg.ExcludedScopes, ",") != "release,ci" {
+		t.Errorf("expected excluded scopes [release ci], got %v", cfg.ExcludedScopes)
+	}
+
+	if _, ok := cfg.CommitlintRules["header-max-length"]; ok {
+		t.Error("header-max-length should be removed by null")
+	}
+	if _, ok := cfg.CommitlintRules["body-max-line-length"]; !ok {
+		t.Error("body-max-line-length should be inherited")
+	}
+}
+
+func TestLoadExtendsOverrideHidesType(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"base.json": sharedConfig,
+		"commit-types.json": `{
+  "extends": "base.json",
+  "types": {"fix": {"changelog_group": null}}
+}`,
+	})
+
+	cfg, err := Load(filepath.Join(dir, "commit-types.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fix, ok := cfg.Types["fix"]
+	if !ok {
+		t.Fatal("fix should still exist")
+	}
+	if fix.ChangelogGroup != nil {
+		t.Error("fix changelog_group should be overridden to null")
+	}
+	if fix.Bump != "patch" {
+		t.Errorf("fix bump should be inherited, got %q", fix.Bump)
+	}
+}
+
+func TestLoadExtendsMultiple(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.json":            `{"description": "a", "types": {"feat": {"description": "from a", "bump": "minor"}}}`,
+		"b.yaml":            "description: b\ntypes:\n  feat:\n    description: from b\n",
+		"commit-types.json": `{"extends": ["a.json", "b.yaml"]}`,
+	})
+
+	cfg, err := Load(filepath.Join(dir, "commit-types.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Description != "b" {
+		t.Errorf("later extends should win, got %q", cfg.Description)
+	}
+	if cfg.Types["feat"].Description != "from b" || cfg.Types["feat"].Bump != "minor" {
+		t.Errorf("unexpected feat: %+v", cfg.Types["feat"])
+	}
+}
+
+func TestLoadExtendsChain(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"org.json":          sharedConfig,
+		"team/team.json":    `{"extends": "../org.json", "types": {"chore": null}}`,
+		"commit-types.json": `{"extends": "team/team.json", "types": {"ci": {"description": "CI"}}}`,
+	})
+
+	cfg, err := Load(filepath.Join(dir, "commit-types.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := cfg.Types["chore"]; ok {
+		t.Error("chore removed by the intermediate file should stay removed")
+	}
+	if _, ok := cfg.Types["ci"]; !ok {
+		t.Error("ci should be added")
+	}
+	if _, ok := cfg.Types["feat"]; !ok {
+		t.Error("feat should be inherited through the chain")
+	}
+}
+
+func TestLoadExtendsCycle(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.json": `{"extends": "b.json", "types": {}}`,
+		"b.json": `{"extends": "a.json", "types": {}}`,
+	})
+
+	_, err := Load(filepath.Join(dir, "a.json"))
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+}
+
+func TestLoadExtendsMissingFile(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"commit-types.json": `{"extends": "missing.json"}`,
+	})
+
+	_, err := Load(filepath.Join(dir, "commit-types.json"))
+	if err == nil || !strings.Contains(err.Error(), "missing.json") {
+		t.Errorf("expected error naming missing.json, got %v", err)
+	}
+}
+
+func TestLoadExtendsInvalidBase(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"base.json":         `{"types": {"feat": {"bump": "minr"}}}`,
+		"commit-types.json": `{"extends": "base.json", "types": {}}`,
+	})
+
+	_, err := Load(filepath.Join(dir, "commit-types.json"))
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if !strings.HasSuffix(verr.File, "base.json") {
+		t.Errorf("expected problems attributed to base.json, got %q", verr.File)
+	}
+}
+
+func TestLoadExtendsScopes(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"base.json": `{
+  "types": {"feat": {"description": "feature"}},
+  "scopes": {
+    "api": {"description": "The API"},
+    "deps": {"description": "Dependencies", "hidden": true},
+    "legacy": {"description": "Old code"}
+  }
+}`,
+		"commit-types.json": `{
+  "extends": "base.json",
+  "scopes": {"api": {"hidden": true}, "legacy": null, "cli": {"description": "CLI"}}
+}`,
+	})
+
+	cfg, err := Load(filepath.Join(dir, "commit-types.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(cfg.ScopeNames(), ",") != "api,cli,deps" {
+		t.Errorf("unexpected scopes: %v", cfg.ScopeNames())
+	}
+	api := cfg.Scopes["api"]
+	if !api.Hidden || api.Description != "The API" {
+		t.Errorf("api should be hidden and keep its description, got %+v", api)
+	}
+}
+
+func TestLoadExtendsGenerators(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"base.json": `{
+  "types": {"feat": {"description": "feature", "changelog_group": "Added"}},
+  "generators": {
+    "release-please": {"types": {"feat": {"changelog_group": "Features"}}},
+    "changie": {"extra": {"changesFormat": "- {{.Body}}"}}
+  }
+}`,
+		"commit-types.json": `{
+  "extends": "base.json",
+  "generators": {"changie": null, "cliff": {"types": {"feat": {"hidden": true}}}}
+}`,
+	})
+
+	cfg, err := Load(filepath.Join(dir, "commit-types.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := cfg.Generators["changie"]; ok {
+		t.Error("changie overrides should be removed by null")
+	}
+	if _, ok := cfg.Generators["release-please"]; !ok {
+		t.Error("release-please overrides should be inherited")
+	}
+	if _, ok := cfg.Generators["cliff"]; !ok {
+		t.Error("cliff overrides should be added")
+	}
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed presets/*.json
+var presetFS embed.FS
+
+// presetPrefix marks an "extends" entry that refers to a built-in preset
+// rather than a file, e.g. "preset:keepachangelog".
+const presetPrefix = "preset:"
+
+// PresetNames returns the names of the built-in presets in sorted order.
+func PresetNames() []string {
+	entries, _ := presetFS.ReadDir("presets")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preset returns the JSON source of a built-in preset.
+func Preset(name string) ([]byte, error) {
+	data, err := presetFS.ReadFile(path.Join("presets", name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(PresetNames(), ", "))
+	}
+	return data, nil
+}
+
+// Starter returns the contents of a new config file based on a preset, in the
+// format implied by dest's extension. When extend is true the file references
+// the preset through "extends" instead of copying its types.
+func Starter(preset string, extend bool, dest string) ([]byte, error) {
+	data, err := Preset(preset)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc []byte
+	if extend {
+		doc = fmt.Appendf(nil, "{\n  %q: %q,\n  %q: %q\n}\n", "$schema", SchemaURL, "extends", presetPrefix+preset)
+	} else {
+		doc = data
+	}
+
+	switch formatFromPath(dest) {
+	case formatYAML:
+		// Going through yaml.Node keeps the preset's key order.
+		var node yaml.Node
+		if err := yaml.Unmarshal(doc, &node); err != nil {
+			return nil, err
+		}
+		clearStyle(&node)
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&node); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case formatTOML:
+		return starterTOML(doc)
+	default:
+		return doc, nil
+	}
+}
+
+// starterTOML converts a JSON config to TOML, keeping integers and the order
+// of the types.
+func starterTOML(doc []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	var generic map[string]any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	generic = jsonNumbers(generic).(map[string]any)
+	types := asObject(generic["types"])
+	delete(generic, "types")
+	// TOML has no null; an omitted changelog_group means the same thing.
+	for _, t := range types {
+		if fields, ok := t.(map[string]any); ok && fields["changelog_group"] == nil {
+			delete(fields, "changelog_group")
+		}
+	}
+
+	out, err := toml.Marshal(generic)
+	if err != nil {
+		return nil, err
+	}
+	// toml.Marshal sorts map keys, so write the types one by one in the
+	// order the preset lists them.
+	var node yaml.Node
+	if err := yaml.Unmarshal(doc, &node); err != nil {
+		return nil, err
+	}
+	for _, name := range mappingKeys(&node, "types") {
+		table, err := toml.Marshal(map[string]any{"types": map[string]any{name: types[name]}})
+		if err != nil {
+			return nil, err
+		}
+		if len(out) > 0 {
+			out = append(out, '\n')
+		}
+		out = append(out, bytes.TrimPrefix(table, []byte("[types]\n"))...)
+	}
+	return out, nil
+}
+
+// jsonNumbers replaces the json.Numbers in v with int64s, or float64s when
+// they aren't integers.
+func jsonNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, child := range v {
+			v[k] = jsonNumbers(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = jsonNumbers(child)
+		}
+	}
+	return v
+}
+
+// mappingKeys returns the keys of the mapping under key in a parsed document,
+// in document order.
+func mappingKeys(doc *yaml.Node, key string) []string {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != key {
+			continue
+		}
+		var keys []string
+		m := root.Content[i+1]
+		for j := 0; j+1 < len(m.Content); j += 2 {
+			keys = append(keys, m.Content[j].Value)
+		}
+		return keys
+	}
+	return nil
+}
+
+// clearStyle switches a node tree parsed from JSON to YAML block style with
+// plain scalars wherever quoting isn't required.
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, child := range n.Content {
+		clearStyle(child)
+	}
+}
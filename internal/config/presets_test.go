@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPresetNames(t *testing.T) {
+	names := PresetNames()
+	expected := []string{"angular", "keepachangelog", "minimal"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected presets %v, got %v", expected, names)
+	}
+}
+
+func TestPresetsAreValid(t *testing.T) {
+	for _, name := range PresetNames() {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "commit-types.json")
+			data, err := Preset(name)
+			if err != nil {
+				t.Fatalf("reading preset: %v", err)
+			}
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				t.Fatalf("writing test file: %v", err)
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("preset does not load: %v", err)
+			}
+			if problems := cfg.Validate(); len(problems) != 0 {
+				t.Errorf("preset has problems: %v", problems)
+			}
+		})
+	}
+}
+
+func TestPresetUnknown(t *testing.T) {
+	_, err := Preset("nope")
+	if err == nil || !strings.Contains(err.Error(), "keepachangelog") {
+		t.Errorf("expected error listing available presets, got %v", err)
+	}
+}
+
+func TestLoadExtendsPreset(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "commit-types.json")
+	data := []byte(`{
+  "extends": "preset:keepachangelog",
+  "types": {"data": {"description": "Data changes", "changelog_group": "Data"}, "deps": null}
+}`)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g := cfg.Types["feat"].ChangelogGroup; g == nil || *g != "Added" {
+		t.Errorf("feat should come from the preset, got %v", g)
+	}
+	if _, ok := cfg.Types["data"]; !ok {
+		t.Error("data should be added")
+	}
+	if _, ok := cfg.Types["deps"]; ok {
+		t.Error("deps should be removed")
+	}
+}
+
+func TestStarter(t *testing.T) {
+	for _, name := range []string{"commit-types.json", "commit-types.yaml", "commit-types.toml"} {
+		for _, extend := range []bool{false, true} {
+			dir := t.TempDir()
+			path := filepath.Join(dir, name)
+			data, err := Starter("angular", extend, path)
+			if err != nil {
+				t.Fatalf("%s (extend=%v): %v", name, extend, err)
+			}
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				t.Fatalf("writing test file: %v", err)
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("%s (extend=%v) does not load: %v\n%s", name, extend, err, data)
+			}
+			if len(cfg.Types) != 11 {
+				t.Errorf("%s (extend=%v): expected 11 types, got %d", name, extend, len(cfg.Types))
+			}
+			if cfg.Types["docs"].ChangelogGroup != nil {
+				t.Errorf("%s (extend=%v): docs should be hidden", name, extend)
+			}
+			if extend != strings.Contains(string(data), "preset:angular") {
+				t.Errorf("%s (extend=%v): unexpected extends in output:\n%s", name, extend, data)
+			}
+		}
+	}
+}
+
+func TestStarterTOMLKeepsIntegersAndOrder(t *testing.T) {
+	data, err := Starter("keepachangelog", false, "commit-types.toml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := string(data)
+	if !strings.Contains(s, "body-max-line-length = [0, 'always', 200]\n") {
+		t.Errorf("integers should stay integers:\n%s", s)
+	}
+	feat, fix, docs := strings.Index(s, "[types.feat]"), strings.Index(s, "[types.fix]"), strings.Index(s, "[types.docs]")
+	if feat < 0 || feat > fix || fix > docs {
+		t.Errorf("types should keep the preset's order:\n%s", s)
+	}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// SchemaVersion is the version of the embedded JSON Schema.
+const SchemaVersion = 1
+
+// SchemaURL is the canonical $id of the embedded JSON Schema. Point a config's
+// "$schema" field here to get editor autocomplete.
+const SchemaURL = "https://raw.githubusercontent.com/tylerbutler/commit-config-gen/main/internal/config/schema/commit-types.v1.json"
+
+//go:embed schema/commit-types.v1.json
+var schemaJSON []byte
+
+// schemaVersionPattern extracts the version from a "$schema" URL that points
+// at one of our schemas.
+var schemaVersionPattern = regexp.MustCompile(`commit-types\.v(\d+)\.json$`)
+
+var (
+	schemaOnce     sync.Once
+	compiledSchema *jsonschema.Schema
+	schemaErr      error
+)
+
+// Schema returns the embedded JSON Schema for commit-types.json.
+func Schema() []byte {
+	return schemaJSON
+}
+
+func loadSchema() (*jsonschema.Schema, error) {
+	schemaOnce.Do(func() {
+		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaJSON))
+		if err != nil {
+			schemaErr = fmt.Errorf("parsing embedded schema: %w", err)
+			return
+		}
+		c := jsonschema.NewCompiler()
+		if err := c.AddResource(SchemaURL, doc); err != nil {
+			schemaErr = fmt.Errorf("adding embedded schema: %w", err)
+			return
+		}
+		compiledSchema, schemaErr = c.Compile(SchemaURL)
+	})
+	return compiledSchema, schemaErr
+}
+
+// validateSchema checks a decoded document against the embedded JSON Schema.
+// doc must use the generic JSON data model (map[string]any, []any, json.Number, ...).
+// Schema violations are reported as a *ValidationError.
+func validateSchema(doc any) error {
+	if obj, ok := doc.(map[string]any); ok {
+		if ref, ok := obj["$schema"].(string); ok {
+			if m := schemaVersionPattern.FindStringSubmatch(ref); m != nil && m[1] != strconv.Itoa(SchemaVersion) {
+				return fmt.Errorf("unsupported schema version v%s (this build supports v%d)", m[1], SchemaVersion)
+			}
+		}
+	}
+
+	sch, err := loadSchema()
+	if err != nil {
+		return err
+	}
+
+	err = sch.Validate(doc)
+	if err == nil {
+		return nil
+	}
+	verr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return err
+	}
+
+	printer := message.NewPrinter(language.English)
+	var problems []Problem
+	var collect func(e *jsonschema.ValidationError)
+	collect = func(e *jsonschema.ValidationError) {
+		// propertyNames failures are reported against the name itself. The
+		// validator leaves them without an instance location, so the object
+		// holding the name is found from the keyword's place in the schema.
+		if pn, ok := e.ErrorKind.(*kind.PropertyNames); ok {
+			path := instancePath(append(schemaProperties(e.SchemaURL), pn.Property))
+			for _, cause := range leafErrors(e) {
+				problems = append(problems, Problem{
+					Path:    path,
+					Message: "invalid name: " + cause.ErrorKind.LocalizedString(printer),
+				})
+			}
+			return
+		}
+		if len(e.Causes) == 0 {
+			problems = append(problems, Problem{
+				Path:    instancePath(e.InstanceLocation),
+				Message: e.ErrorKind.LocalizedString(printer),
+			})
+			return
+		}
+		for _, cause := range e.Causes {
+			collect(cause)
+		}
+	}
+	collect(verr)
+
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].Path != problems[j].Path {
+			return problems[i].Path < problems[j].Path
+		}
+		return problems[i].Message < problems[j].Message
+	})
+	return &ValidationError{Problems: problems}
+}
+
+func leafErrors(e *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(e.Causes) == 0 {
+		return []*jsonschema.ValidationError{e}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range e.Causes {
+		leaves = append(leaves, leafErrors(cause)...)
+	}
+	return leaves
+}
+
+// schemaProperties returns the instance location a schema keyword applies
+// to, from the properties it is nested under in the keyword's URL.
+func schemaProperties(url string) []string {
+	_, pointer, _ := strings.Cut(url, "#")
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	var path []string
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i] == "properties" {
+			i++
+			path = append(path, unescape.Replace(tokens[i]))
+		}
+	}
+	return path
+}
+
+// instancePath renders a JSON Pointer token list in the dotted form used by Problem.
+func instancePath(tokens []string) string {
+	path := ""
+	for _, tok := range tokens {
+		switch _, err := strconv.Atoi(tok); {
+		case err == nil:
+			path += "[" + tok + "]"
+		case path == "":
+			path = strings.TrimPrefix(jsonPath("", tok), ".")
+		default:
+			path = jsonPath(path, tok)
+		}
+	}
+	return path
+}
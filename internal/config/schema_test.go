@@ -0,0 +1,123 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+	return path
+}
+
+func TestSchemaIsValidJSON(t *testing.T) {
+	var doc map[string]any
+	if err := json.Unmarshal(Schema(), &doc); err != nil {
+		t.Fatalf("embedded schema is not valid JSON: %v", err)
+	}
+	if doc["$id"] != SchemaURL {
+		t.Errorf("schema $id %q does not match SchemaURL %q", doc["$id"], SchemaURL)
+	}
+	if _, err := loadSchema(); err != nil {
+		t.Fatalf("embedded schema does not compile: %v", err)
+	}
+}
+
+func TestLoadSchemaViolations(t *testing.T) {
+	path := writeConfig(t, "commit-types.json", `{
+  "types": {
+    "feat": {"description": "feature", "chagelog_group": "Added", "bump": "minr"},
+    "fe at": {"description": "bad name"}
+  },
+  "commitlint_rules": {"header-max-length": [5, "always", 100]}
+}`)
+
+	_, err := Load(path)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, p := range verr.Problems {
+		got[p.Path] = true
+	}
+	for _, path := range []string{"types.feat", "types.feat.bump", `types["fe at"]`, "commitlint_rules.header-max-length[0]"} {
+		if !got[path] {
+			t.Errorf("missing problem at %s (got %v)", path, verr.Problems)
+		}
+	}
+}
+
+func TestLoadSchemaInvalidName(t *testing.T) {
+	path := writeConfig(t, "commit-types.json", `{"types": {"fix!": {"description": "bad name"}}}`)
+
+	_, err := Load(path)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if len(verr.Problems) != 1 || verr.Problems[0].Path != "types.fix!" {
+		t.Errorf("expected one problem at types.fix!, got %v", verr.Problems)
+	}
+}
+
+func TestLoadSchemaMissingTypes(t *testing.T) {
+	path := writeConfig(t, "commit-types.json", `{"description": "no types"}`)
+
+	_, err := Load(path)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if !strings.Contains(verr.Error(), "types") {
+		t.Errorf("error should mention missing types: %v", verr)
+	}
+}
+
+func TestLoadSchemaUnknownTopLevelField(t *testing.T) {
+	path := writeConfig(t, "commit-types.json", `{"types": {}, "exclude_scopes": ["release"]}`)
+
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), "exclude_scopes") {
+		t.Errorf("expected error mentioning exclude_scopes, got %v", err)
+	}
+}
+
+func TestLoadSchemaVersion(t *testing.T) {
+	path := writeConfig(t, "commit-types.json", `{"$schema": "`+SchemaURL+`", "types": {}}`)
+	if _, err := Load(path); err != nil {
+		t.Errorf("unexpected error for current schema version: %v", err)
+	}
+
+	future := strings.Replace(SchemaURL, "v1.json", "v99.json", 1)
+	path = writeConfig(t, "commit-types.json", `{"$schema": "`+future+`", "types": {}}`)
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "v99") {
+		t.Errorf("expected unsupported version error, got %v", err)
+	}
+}
+
+func TestInstancePath(t *testing.T) {
+	tests := []struct {
+		tokens []string
+		want   string
+	}{
+		{nil, ""},
+		{[]string{"types", "feat", "bump"}, "types.feat.bump"},
+		{[]string{"excluded_scopes", "2"}, "excluded_scopes[2]"},
+		{[]string{"types", "a.b"}, `types["a.b"]`},
+	}
+	for _, tt := range tests {
+		if got := instancePath(tt.tokens); got != tt.want {
+			t.Errorf("instancePath(%v) = %q, want %q", tt.tokens, got, tt.want)
+		}
+	}
+}
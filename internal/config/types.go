@@ -3,14 +3,104 @@ package config
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	pathpkg "path"
+	"slices"
+	"sort"
 )
 
 // CommitType defines a single commit type configuration
 type CommitType struct {
 	Description    string  `json:"description"`
-	ChangelogGroup *string `json:"changelog_group"` // nil means excluded from changelog
-	Bump           string  `json:"bump,omitempty"`  // "major", "minor", "patch", or "none"
+	ChangelogGroup *string `json:"changelog_group"`   // nil means excluded from changelog
+	Bump           string  `json:"bump,omitempty"`    // "major", "minor", "patch", or "none"
+	Aliases        []Alias `json:"aliases,omitempty"` // legacy prefixes treated as this type
+	Emoji          string  `json:"emoji,omitempty"`   // shown next to the type in commit prompts
+}
+
+// Alias is an alternate prefix for a commit type, e.g. "feature" for "feat".
+// In JSON it is either a bare name or an object with "name" and "allow".
+type Alias struct {
+	Name  string `json:"name"`
+	Allow bool   `json:"allow,omitempty"` // also accept in commitlint's type-enum
+}
+
+func (a *Alias) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*a = Alias{Name: name}
+		return nil
+	}
+	type plain Alias
+	return json.Unmarshal(data, (*plain)(a))
+}
+
+func (a Alias) MarshalJSON() ([]byte, error) {
+	if !a.Allow {
+		return json.Marshal(a.Name)
+	}
+	type plain Alias
+	return json.Marshal(plain(a))
+}
+
+// Scope defines a single commit scope configuration
+type Scope struct {
+	Description string   `json:"description,omitempty"`
+	Hidden      bool     `json:"hidden,omitempty"` // skip commits with this scope in changelogs
+	Types       []string `json:"types,omitempty"`  // types allowed with this scope; empty allows all
+}
+
+// AllowsType reports whether commits of the given type may use this scope.
+func (s Scope) AllowsType(name string) bool {
+	return len(s.Types) == 0 || slices.Contains(s.Types, name)
+}
+
+// Breaking configures how breaking changes ("type!:" or a "BREAKING CHANGE:"
+// footer) are grouped and bumped.
+type Breaking struct {
+	ChangelogGroup *string  `json:"changelog_group,omitempty"` // nil leaves breaking commits in their type's group
+	Bump           string   `json:"bump,omitempty"`            // defaults to "major"
+	Types          []string `json:"types,omitzero"`            // types that may use "!"; nil allows all, empty allows none
+}
+
+// BumpLevel returns the bump level for breaking changes, defaulting to "major".
+func (b *Breaking) BumpLevel() string {
+	if b == nil || b.Bump == "" {
+		return "major"
+	}
+	return b.Bump
+}
+
+// AllowsBang reports whether commits of the given type may be marked breaking with "!".
+func (b *Breaking) AllowsBang(name string) bool {
+	return b == nil || b.Types == nil || slices.Contains(b.Types, name)
+}
+
+// Dependencies configures the commit messages written by dependency update
+// bots such as Renovate and Dependabot.
+type Dependencies struct {
+	Type  string `json:"type,omitempty"`  // defaults to "deps" when that type exists
+	Scope string `json:"scope,omitempty"` // optional scope, e.g. "deps"
+}
+
+// GeneratorConfig holds overrides that apply to a single generator.
+type GeneratorConfig struct {
+	Types map[string]TypeOverride `json:"types,omitempty"`
+	Extra map[string]any          `json:"extra,omitempty"` // deep-merged into the generated file
+}
+
+// TypeOverride replaces selected fields of a CommitType for one generator.
+type TypeOverride struct {
+	ChangelogGroup *string `json:"changelog_group,omitempty"`
+	Bump           string  `json:"bump,omitempty"`
+	Hidden         *bool   `json:"hidden,omitempty"` // true drops the changelog group
+}
+
+// Package configures one package of a monorepo. Packages are keyed by their
+// path relative to the repository root.
+type Package struct {
+	Name           string                  `json:"name,omitempty"`            // defaults to the last path element
+	ExcludedScopes []string                `json:"excluded_scopes,omitempty"` // added to the top-level list
+	Types          map[string]TypeOverride `json:"types,omitempty"`
 }
 
 // CommitlintRule represents a commitlint rule configuration
@@ -18,18 +108,33 @@ type CommitlintRule = []any
 
 // Config represents the commit-types.json structure
 type Config struct {
-	Schema          string                    `json:"$schema,omitempty"`
-	Description     string                    `json:"description,omitempty"`
-	Types           map[string]CommitType     `json:"types"`
-	ExcludedScopes  []string                  `json:"excluded_scopes,omitempty"`
-	CommitlintRules map[string]CommitlintRule `json:"commitlint_rules,omitempty"`
+	Schema          string                     `json:"$schema,omitempty"`
+	Description     string                     `json:"description,omitempty"`
+	Types           map[string]CommitType      `json:"types"`
+	Order           []string                   `json:"order,omitempty"` // explicit type order; unlisted types follow
+	Scopes          map[string]Scope           `json:"scopes,omitempty"`
+	ExcludedScopes  []string                   `json:"excluded_scopes,omitempty"`
+	Breaking        *Breaking                  `json:"breaking,omitempty"`
+	Dependencies    *Dependencies              `json:"dependencies,omitempty"`
+	Packages        map[string]Package         `json:"packages,omitempty"`
+	Generators      map[string]GeneratorConfig `json:"generators,omitempty"`
+	CommitlintRules map[string]CommitlintRule  `json:"commitlint_rules,omitempty"`
 }
 
-// Load reads and parses a commit-types.json file
+// Load reads and parses a commit-types file, validating it against the
+// embedded JSON Schema. The format is picked from the extension: .yaml/.yml
+// and .toml are supported alongside JSON. Files listed in "extends" are
+// loaded first and the file's own settings are merged on top. Schema
+// violations are returned as a *ValidationError.
 func Load(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
+	doc, err := loadDocument(path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("reading config file: %w", err)
+		return nil, err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("encoding resolved config: %w", err)
 	}
 
 	var cfg Config
@@ -51,28 +156,162 @@ func (c *Config) VisibleTypes() map[string]CommitType {
 	return visible
 }
 
-// TypeNames returns all type names in a consistent order
+// defaultTypeOrder positions well-known types that are not listed in Config.Order.
+var defaultTypeOrder = []string{"feat", "fix", "improvement", "perf", "refactor", "docs", "style", "test", "build", "ci", "chore", "revert", "data"}
+
+// TypeNames returns all type names in a deterministic order: types listed in
+// Config.Order first, then well-known types in their conventional order, then
+// any remaining types alphabetically.
 func (c *Config) TypeNames() []string {
-	// Use a predefined order for consistency
-	order := []string{"feat", "fix", "improvement", "perf", "refactor", "docs", "style", "test", "build", "ci", "chore", "revert", "data"}
-	var names []string
-	for _, name := range order {
-		if _, ok := c.Types[name]; ok {
+	names := make([]string, 0, len(c.Types))
+	seen := make(map[string]bool, len(c.Types))
+	add := func(name string) {
+		if _, ok := c.Types[name]; ok && !seen[name] {
+			seen[name] = true
 			names = append(names, name)
 		}
 	}
-	// Add any types not in the predefined order
+
+	for _, name := range c.Order {
+		add(name)
+	}
+	for _, name := range defaultTypeOrder {
+		add(name)
+	}
+
+	var rest []string
 	for name := range c.Types {
-		found := false
-		for _, n := range names {
-			if n == name {
-				found = true
-				break
-			}
+		if !seen[name] {
+			rest = append(rest, name)
 		}
-		if !found {
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// ScopeNames returns all known scope names: the keys of Scopes plus any
+// excluded scopes, sorted alphabetically.
+func (c *Config) ScopeNames() []string {
+	names := make([]string, 0, len(c.Scopes)+len(c.ExcludedScopes))
+	for name := range c.Scopes {
+		names = append(names, name)
+	}
+	for _, name := range c.ExcludedScopes {
+		if _, ok := c.Scopes[name]; !ok {
 			names = append(names, name)
 		}
 	}
-	return names
+	sort.Strings(names)
+	return slices.Compact(names)
+}
+
+// HiddenScopes returns the scopes whose commits are skipped in changelogs:
+// ExcludedScopes in their declared order, then scopes marked hidden,
+// alphabetically.
+func (c *Config) HiddenScopes() []string {
+	hidden := slices.Clone(c.ExcludedScopes)
+	var extra []string
+	for name, s := range c.Scopes {
+		if s.Hidden && !slices.Contains(hidden, name) {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	return append(hidden, extra...)
+}
+
+// ForGenerator returns the config as seen by the named generator: a copy with
+// that generator's type overrides applied. The receiver is not modified.
+func (c *Config) ForGenerator(name string) *Config {
+	return c.withTypeOverrides(c.Generators[name].Types)
+}
+
+// DependencyType returns the commit type for dependency updates: the
+// configured type, else "deps" if the config defines it, else "".
+func (c *Config) DependencyType() string {
+	if c.Dependencies != nil && c.Dependencies.Type != "" {
+		return c.Dependencies.Type
+	}
+	if _, ok := c.Types["deps"]; ok {
+		return "deps"
+	}
+	return ""
+}
+
+// DependencyScope returns the scope for dependency updates, or "".
+func (c *Config) DependencyScope() string {
+	if c.Dependencies == nil {
+		return ""
+	}
+	return c.Dependencies.Scope
+}
+
+// PackagePaths returns the configured package paths in sorted order.
+func (c *Config) PackagePaths() []string {
+	paths := make([]string, 0, len(c.Packages))
+	for p := range c.Packages {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// PackageName returns the name of the package at path.
+func (c *Config) PackageName(path string) string {
+	if name := c.Packages[path].Name; name != "" {
+		return name
+	}
+	return pathpkg.Base(path)
+}
+
+// ForPackage returns the config as seen by the package at path: a copy with
+// the package's excluded scopes added and its type overrides applied. The
+// receiver is not modified.
+func (c *Config) ForPackage(path string) *Config {
+	pkg := c.Packages[path]
+	out := c.withTypeOverrides(pkg.Types)
+	if len(pkg.ExcludedScopes) > 0 {
+		if out == c {
+			copied := *c
+			out = &copied
+		}
+		out.ExcludedScopes = slices.Clone(c.ExcludedScopes)
+		for _, scope := range pkg.ExcludedScopes {
+			if !slices.Contains(out.ExcludedScopes, scope) {
+				out.ExcludedScopes = append(out.ExcludedScopes, scope)
+			}
+		}
+	}
+	return out
+}
+
+// withTypeOverrides returns a copy of the config with overrides applied to
+// its types, or the config itself when there are none.
+func (c *Config) withTypeOverrides(overrides map[string]TypeOverride) *Config {
+	if len(overrides) == 0 {
+		return c
+	}
+
+	out := *c
+	out.Types = make(map[string]CommitType, len(c.Types))
+	for typeName, t := range c.Types {
+		if o, ok := overrides[typeName]; ok {
+			if o.ChangelogGroup != nil {
+				t.ChangelogGroup = o.ChangelogGroup
+			}
+			if o.Bump != "" {
+				t.Bump = o.Bump
+			}
+			if o.Hidden != nil && *o.Hidden {
+				t.ChangelogGroup = nil
+			}
+		}
+		out.Types[typeName] = t
+	}
+	return &out
+}
+
+// GeneratorExtra returns the extra settings configured for the named generator.
+func (c *Config) GeneratorExtra(name string) map[string]any {
+	return c.Generators[name].Extra
 }
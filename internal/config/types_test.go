@@ -1,8 +1,11 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -249,14 +252,59 @@ func TestTypeNamesAllUnknown(t *testing.T) {
 		t.Fatalf("expected 3 names, got %d", len(names))
 	}
 
-	// All three should be present (order among unknowns is map iteration order, not guaranteed)
-	found := map[string]bool{}
-	for _, n := range names {
-		found[n] = true
+	// Types outside the predefined order are sorted alphabetically
+	expected := []string{"alpha", "beta", "gamma"}
+	for i, name := range names {
+		if name != expected[i] {
+			t.Errorf("position %d: expected %q, got %q", i, expected[i], name)
+		}
+	}
+}
+
+func TestTypeNamesDeterministic(t *testing.T) {
+	cfg := &Config{
+		Types: map[string]CommitType{
+			"feat":     {Description: "feature"},
+			"security": {Description: "security"},
+			"deps":     {Description: "deps"},
+			"wip":      {Description: "wip"},
+			"chore":    {Description: "chore"},
+		},
+	}
+
+	first := cfg.TypeNames()
+	for i := 0; i < 50; i++ {
+		names := cfg.TypeNames()
+		for j := range names {
+			if names[j] != first[j] {
+				t.Fatalf("order changed between calls: %v vs %v", first, names)
+			}
+		}
+	}
+}
+
+func TestTypeNamesExplicitOrder(t *testing.T) {
+	cfg := &Config{
+		Types: map[string]CommitType{
+			"feat":     {Description: "feature"},
+			"fix":      {Description: "fix"},
+			"security": {Description: "security"},
+			"deps":     {Description: "deps"},
+			"chore":    {Description: "chore"},
+			"zzz":      {Description: "custom"},
+		},
+		Order: []string{"security", "feat", "deps", "missing"},
+	}
+
+	names := cfg.TypeNames()
+	// Listed types first, then predefined order, then alphabetical
+	expected := []string{"security", "feat", "deps", "fix", "chore", "zzz"}
+	if len(names) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
 	}
-	for _, expected := range []string{"alpha", "beta", "gamma"} {
-		if !found[expected] {
-			t.Errorf("missing expected type %q", expected)
+	for i, name := range names {
+		if name != expected[i] {
+			t.Errorf("position %d: expected %q, got %q", i, expected[i], name)
 		}
 	}
 }
@@ -293,3 +341,352 @@ func TestTypeNamesFullPredefinedOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "commit-types.yaml")
+	data := []byte(`# Shared commit types
+description: test config
+types:
+  feat:
+    description: A new feature
+    changelog_group: Added
+    bump: minor
+  # Hidden from changelogs on purpose
+  chore:
+    description: Chores
+    changelog_group: null
+excluded_scopes: [release]
+commitlint_rules:
+  body-max-line-length: [0, always, 200]
+`)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Description != "test config" {
+		t.Errorf("expected description 'test config', got %q", cfg.Description)
+	}
+	if cfg.Types["feat"].ChangelogGroup == nil || *cfg.Types["feat"].ChangelogGroup != "Added" {
+		t.Errorf("unexpected feat changelog_group: %v", cfg.Types["feat"].ChangelogGroup)
+	}
+	if cfg.Types["chore"].ChangelogGroup != nil {
+		t.Error("chore changelog_group should be nil")
+	}
+	if len(cfg.ExcludedScopes) != 1 || cfg.ExcludedScopes[0] != "release" {
+		t.Errorf("unexpected excluded_scopes: %v", cfg.ExcludedScopes)
+	}
+	rule := cfg.CommitlintRules["body-max-line-length"]
+	if len(rule) != 3 || rule[0] != float64(0) || rule[1] != "always" {
+		t.Errorf("unexpected commitlint rule: %v", rule)
+	}
+	if problems := cfg.Validate(); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "commit-types.toml")
+	data := []byte(`description = "test config"
+excluded_scopes = ["release"]
+
+[types.feat]
+description = "A new feature"
+changelog_group = "Added"
+bump = "minor"
+
+# TOML has no null; omit changelog_group to hide a type
+[types.chore]
+description = "Chores"
+
+[commitlint_rules]
+body-max-line-length = [0, "always", 200]
+`)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Types["feat"].Bump != "minor" {
+		t.Errorf("expected feat bump 'minor', got %q", cfg.Types["feat"].Bump)
+	}
+	if cfg.Types["chore"].ChangelogGroup != nil {
+		t.Error("chore changelog_group should be nil")
+	}
+	if len(cfg.ExcludedScopes) != 1 || cfg.ExcludedScopes[0] != "release" {
+		t.Errorf("unexpected excluded_scopes: %v", cfg.ExcludedScopes)
+	}
+	if problems := cfg.Validate(); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+}
+
+func TestLoadYAMLSchemaViolation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "commit-types.yml")
+	if err := os.WriteFile(path, []byte("types:\n  feat:\n    bump: minr\n"), 0o644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	_, err := Load(path)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if len(verr.Problems) != 1 || verr.Problems[0].Path != "types.feat.bump" {
+		t.Errorf("expected types.feat.bump problem, got %v", verr.Problems)
+	}
+}
+
+func TestLoadInvalidTOML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "commit-types.toml")
+	if err := os.WriteFile(path, []byte("[types\n"), 0o644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Error("expected error for invalid TOML")
+	}
+}
+
+func TestScopeNames(t *testing.T) {
+	cfg := &Config{
+		Scopes: map[string]Scope{
+			"parser": {Description: "The parser"},
+			"cli":    {Description: "Command line"},
+			"deps":   {Hidden: true},
+		},
+		ExcludedScopes: []string{"release", "deps"},
+	}
+
+	names := cfg.ScopeNames()
+	expected := []string{"cli", "deps", "parser", "release"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestHiddenScopes(t *testing.T) {
+	cfg := &Config{
+		Scopes: map[string]Scope{
+			"parser":   {Description: "The parser"},
+			"internal": {Hidden: true},
+			"deps":     {Hidden: true},
+			"release":  {Hidden: true},
+		},
+		ExcludedScopes: []string{"release", "ci"},
+	}
+
+	hidden := cfg.HiddenScopes()
+	expected := []string{"release", "ci", "deps", "internal"}
+	if strings.Join(hidden, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, hidden)
+	}
+}
+
+func TestScopeAllowsType(t *testing.T) {
+	open := Scope{}
+	if !open.AllowsType("feat") {
+		t.Error("scope without types should allow every type")
+	}
+
+	deps := Scope{Types: []string{"deps", "chore"}}
+	if !deps.AllowsType("chore") {
+		t.Error("chore should be allowed")
+	}
+	if deps.AllowsType("feat") {
+		t.Error("feat should not be allowed")
+	}
+}
+
+func TestBreakingDefaults(t *testing.T) {
+	var none *Breaking
+	if none.BumpLevel() != "major" {
+		t.Errorf("expected default bump major, got %q", none.BumpLevel())
+	}
+	if !none.AllowsBang("feat") {
+		t.Error("'!' should be allowed without a breaking section")
+	}
+
+	b := &Breaking{Bump: "minor", Types: []string{"feat"}}
+	if b.BumpLevel() != "minor" {
+		t.Errorf("expected bump minor, got %q", b.BumpLevel())
+	}
+	if !b.AllowsBang("feat") || b.AllowsBang("fix") {
+		t.Error("only feat should allow '!'")
+	}
+
+	b = &Breaking{Types: []string{}}
+	if b.AllowsBang("feat") {
+		t.Error("an empty types list should allow no '!'")
+	}
+}
+
+func TestLoadBreakingEmptyTypes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "commit-types.json")
+	data := []byte(`{
+  "types": {"feat": {"description": "feature"}},
+  "breaking": {"changelog_group": "Breaking", "types": []}
+}`)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Breaking == nil || cfg.Breaking.Types == nil || len(cfg.Breaking.Types) != 0 {
+		t.Errorf("expected empty, non-nil breaking types, got %+v", cfg.Breaking)
+	}
+}
+
+func TestLoadAliases(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "commit-types.json")
+	data := []byte(`{
+  "types": {
+    "feat": {"description": "feature", "changelog_group": "Added", "aliases": ["feature", {"name": "new", "allow": true}]}
+  }
+}`)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	aliases := cfg.Types["feat"].Aliases
+	if len(aliases) != 2 {
+		t.Fatalf("expected 2 aliases, got %v", aliases)
+	}
+	if aliases[0] != (Alias{Name: "feature"}) {
+		t.Errorf("unexpected first alias: %+v", aliases[0])
+	}
+	if aliases[1] != (Alias{Name: "new", Allow: true}) {
+		t.Errorf("unexpected second alias: %+v", aliases[1])
+	}
+
+	out, err := json.Marshal(aliases)
+	if err != nil {
+		t.Fatalf("marshaling aliases: %v", err)
+	}
+	if string(out) != `["feature",{"name":"new","allow":true}]` {
+		t.Errorf("aliases should round-trip to their short form, got %s", out)
+	}
+}
+
+func TestForGenerator(t *testing.T) {
+	added := "Added"
+	features := "Features"
+	hidden := true
+	cfg := &Config{
+		Types: map[string]CommitType{
+			"feat":  {Description: "feature", ChangelogGroup: &added, Bump: "minor"},
+			"perf":  {Description: "performance", ChangelogGroup: &added, Bump: "patch"},
+			"chore": {Description: "chores"},
+		},
+		Generators: map[string]GeneratorConfig{
+			"release-please": {
+				Types: map[string]TypeOverride{
+					"feat": {ChangelogGroup: &features, Bump: "major"},
+					"perf": {Hidden: &hidden},
+				},
+				Extra: map[string]any{"bump-minor-pre-major": true},
+			},
+		},
+	}
+
+	rp := cfg.ForGenerator("release-please")
+	if g := rp.Types["feat"].ChangelogGroup; g == nil || *g != "Features" {
+		t.Errorf("feat group should be overridden, got %v", g)
+	}
+	if rp.Types["feat"].Bump != "major" {
+		t.Errorf("feat bump should be overridden, got %q", rp.Types["feat"].Bump)
+	}
+	if rp.Types["feat"].Description != "feature" {
+		t.Error("feat description should be kept")
+	}
+	if rp.Types["perf"].ChangelogGroup != nil {
+		t.Error("perf should be hidden")
+	}
+
+	if g := cfg.Types["feat"].ChangelogGroup; *g != "Added" || cfg.Types["perf"].ChangelogGroup == nil {
+		t.Error("ForGenerator should not modify the receiver")
+	}
+	if cfg.ForGenerator("changie") != cfg {
+		t.Error("generators without overrides should see the config unchanged")
+	}
+
+	if cfg.GeneratorExtra("release-please")["bump-minor-pre-major"] != true {
+		t.Error("expected release-please extras")
+	}
+	if cfg.GeneratorExtra("changie") != nil {
+		t.Error("expected no changie extras")
+	}
+}
+
+func TestForPackage(t *testing.T) {
+	added := "Added"
+	hidden := true
+	cfg := &Config{
+		Types: map[string]CommitType{
+			"feat": {Description: "feature", ChangelogGroup: &added},
+			"docs": {Description: "docs", ChangelogGroup: &added},
+		},
+		ExcludedScopes: []string{"deps"},
+		Packages: map[string]Package{
+			"packages/web": {ExcludedScopes: []string{"deps", "storybook"}, Types: map[string]TypeOverride{"docs": {Hidden: &hidden}}},
+			".":            {Name: "root"},
+		},
+	}
+
+	if strings.Join(cfg.PackagePaths(), ",") != ".,packages/web" {
+		t.Errorf("unexpected package paths: %v", cfg.PackagePaths())
+	}
+	if cfg.PackageName("packages/web") != "web" || cfg.PackageName(".") != "root" {
+		t.Errorf("unexpected package names: %q, %q", cfg.PackageName("packages/web"), cfg.PackageName("."))
+	}
+
+	web := cfg.ForPackage("packages/web")
+	if strings.Join(web.ExcludedScopes, ",") != "deps,storybook" {
+		t.Errorf("unexpected excluded scopes: %v", web.ExcludedScopes)
+	}
+	if web.Types["docs"].ChangelogGroup != nil {
+		t.Error("docs should be hidden for packages/web")
+	}
+	if strings.Join(cfg.ExcludedScopes, ",") != "deps" || cfg.Types["docs"].ChangelogGroup == nil {
+		t.Error("ForPackage should not modify the receiver")
+	}
+	if cfg.ForPackage(".") != cfg {
+		t.Error("packages without overrides should see the config unchanged")
+	}
+}
+
+func TestDependencyType(t *testing.T) {
+	cfg := &Config{Types: map[string]CommitType{"feat": {}, "chore": {}}}
+	if cfg.DependencyType() != "" {
+		t.Errorf("expected no dependency type, got %q", cfg.DependencyType())
+	}
+	cfg.Types["deps"] = CommitType{}
+	if cfg.DependencyType() != "deps" {
+		t.Errorf("expected deps fallback, got %q", cfg.DependencyType())
+	}
+	cfg.Dependencies = &Dependencies{Type: "chore", Scope: "deps"}
+	if cfg.DependencyType() != "chore" || cfg.DependencyScope() != "deps" {
+		t.Errorf("expected chore(deps), got %q(%q)", cfg.DependencyType(), cfg.DependencyScope())
+	}
+}
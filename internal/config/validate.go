@@ -0,0 +1,290 @@
+package config
+
+import (
+	"fmt"
+	pathpkg "path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// typeNamePattern restricts type names to characters that are safe to splice
+// into the regexes and enum lists emitted by generators.
+var typeNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// scopePattern is like typeNamePattern but also allows "/" for nested scopes.
+var scopePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_/-]*$`)
+
+// validBumps lists the accepted values for CommitType.Bump ("" means unset).
+var validBumps = map[string]bool{"": true, "major": true, "minor": true, "patch": true, "none": true}
+
+// Problem describes a single semantic error in a config.
+type Problem struct {
+	Path    string // JSON path to the offending value, e.g. "types.feat.bump"
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Path == "" {
+		return p.Message
+	}
+	return p.Path + ": " + p.Message
+}
+
+// ValidationError is returned when a config fails schema or semantic validation.
+type ValidationError struct {
+	File     string // set when the problems are in an extended file
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = "  - " + p.String()
+	}
+	header := "invalid config"
+	if e.File != "" {
+		header += " " + e.File
+	}
+	return header + ":\n" + strings.Join(lines, "\n")
+}
+
+// Validate checks the config for semantic errors that JSON decoding cannot
+// catch. Problems are returned in a stable order; an empty result means the
+// config is valid.
+func (c *Config) Validate() []Problem {
+	var problems []Problem
+	add := func(path, format string, args ...any) {
+		problems = append(problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(c.Types) == 0 {
+		add("types", "at least one commit type is required")
+	}
+
+	names := make([]string, 0, len(c.Types))
+	for name := range c.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	aliasOwner := map[string]string{}
+	for _, name := range names {
+		t := c.Types[name]
+		path := jsonPath("types", name)
+		if name == "" {
+			add(path, "type name must not be empty")
+		} else if !typeNamePattern.MatchString(name) {
+			add(path, "type name %q may only contain letters, digits, '-' and '_'", name)
+		}
+		if !validBumps[t.Bump] {
+			add(path+".bump", "invalid bump %q (expected major, minor, patch or none)", t.Bump)
+		}
+		if t.ChangelogGroup != nil && strings.TrimSpace(*t.ChangelogGroup) == "" {
+			add(path+".changelog_group", "must be a non-empty string or null")
+		}
+		for i, alias := range t.Aliases {
+			aliasPath := fmt.Sprintf("%s.aliases[%d]", path, i)
+			owner, dup := aliasOwner[alias.Name]
+			_, isType := c.Types[alias.Name]
+			switch {
+			case !typeNamePattern.MatchString(alias.Name):
+				add(aliasPath, "alias %q may only contain letters, digits, '-' and '_'", alias.Name)
+			case dup:
+				add(aliasPath, "alias %q is already an alias of %q", alias.Name, owner)
+			case isType:
+				add(aliasPath, "alias %q is also a type name", alias.Name)
+			}
+			if !dup {
+				aliasOwner[alias.Name] = name
+			}
+		}
+	}
+
+	ordered := map[string]bool{}
+	for i, name := range c.Order {
+		path := fmt.Sprintf("order[%d]", i)
+		if _, ok := c.Types[name]; !ok {
+			add(path, "unknown type %q", name)
+		} else if ordered[name] {
+			add(path, "duplicate type %q", name)
+		}
+		ordered[name] = true
+	}
+
+	scopeNames := make([]string, 0, len(c.Scopes))
+	for name := range c.Scopes {
+		scopeNames = append(scopeNames, name)
+	}
+	sort.Strings(scopeNames)
+
+	for _, name := range scopeNames {
+		path := jsonPath("scopes", name)
+		if name == "" {
+			add(path, "scope must not be empty")
+		} else if !scopePattern.MatchString(name) {
+			add(path, "scope %q may only contain letters, digits, '-', '_' and '/'", name)
+		}
+		for i, typ := range c.Scopes[name].Types {
+			if _, ok := c.Types[typ]; !ok {
+				add(fmt.Sprintf("%s.types[%d]", path, i), "unknown type %q", typ)
+			}
+		}
+	}
+
+	c.validateExcludedScopes(add, "excluded_scopes", c.ExcludedScopes)
+
+	if b := c.Breaking; b != nil {
+		if b.ChangelogGroup != nil && strings.TrimSpace(*b.ChangelogGroup) == "" {
+			add("breaking.changelog_group", "must be a non-empty string or null")
+		}
+		if b.Bump != "" && b.Bump != "major" && b.Bump != "minor" && b.Bump != "patch" {
+			add("breaking.bump", "invalid bump %q (expected major, minor or patch)", b.Bump)
+		}
+		for i, typ := range b.Types {
+			if _, ok := c.Types[typ]; !ok {
+				add(fmt.Sprintf("breaking.types[%d]", i), "unknown type %q", typ)
+			}
+		}
+	}
+
+	if d := c.Dependencies; d != nil {
+		if _, ok := c.Types[d.Type]; d.Type != "" && !ok {
+			add("dependencies.type", "unknown type %q", d.Type)
+		}
+		if d.Scope != "" && !scopePattern.MatchString(d.Scope) {
+			add("dependencies.scope", "scope %q may only contain letters, digits, '-', '_' and '/'", d.Scope)
+		}
+	}
+
+	for _, p := range c.PackagePaths() {
+		pkgPath := jsonPath("packages", p)
+		if p == "" || pathpkg.IsAbs(p) || pathpkg.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") {
+			add(pkgPath, "package path %q must be a clean path relative to the repository root", p)
+		}
+		if p == "." && c.Packages[p].Name == "" {
+			add(pkgPath+".name", "the root package needs a name")
+		}
+		c.validateExcludedScopes(add, pkgPath+".excluded_scopes", c.Packages[p].ExcludedScopes)
+		c.validateTypeOverrides(add, pkgPath+".types", c.Packages[p].Types)
+	}
+
+	genNames := make([]string, 0, len(c.Generators))
+	for name := range c.Generators {
+		genNames = append(genNames, name)
+	}
+	sort.Strings(genNames)
+
+	for _, gen := range genNames {
+		c.validateTypeOverrides(add, jsonPath("generators", gen)+".types", c.Generators[gen].Types)
+	}
+
+	ruleNames := make([]string, 0, len(c.CommitlintRules))
+	for name := range c.CommitlintRules {
+		ruleNames = append(ruleNames, name)
+	}
+	sort.Strings(ruleNames)
+
+	for _, name := range ruleNames {
+		for _, msg := range validateCommitlintRule(c.CommitlintRules[name]) {
+			add(jsonPath("commitlint_rules", name), "%s", msg)
+		}
+	}
+
+	return problems
+}
+
+func (c *Config) validateExcludedScopes(add func(path, format string, args ...any), parent string, scopes []string) {
+	seen := map[string]bool{}
+	for i, scope := range scopes {
+		path := fmt.Sprintf("%s[%d]", parent, i)
+		switch {
+		case scope == "":
+			add(path, "scope must not be empty")
+		case !scopePattern.MatchString(scope):
+			add(path, "scope %q may only contain letters, digits, '-', '_' and '/'", scope)
+		case seen[scope]:
+			add(path, "duplicate scope %q", scope)
+		}
+		seen[scope] = true
+	}
+}
+
+func (c *Config) validateTypeOverrides(add func(path, format string, args ...any), parent string, overrides map[string]TypeOverride) {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		o := overrides[name]
+		path := jsonPath(parent, name)
+		t, ok := c.Types[name]
+		if !ok {
+			add(path, "unknown type %q", name)
+			continue
+		}
+		if !validBumps[o.Bump] {
+			add(path+".bump", "invalid bump %q (expected major, minor, patch or none)", o.Bump)
+		}
+		if o.ChangelogGroup != nil && strings.TrimSpace(*o.ChangelogGroup) == "" {
+			add(path+".changelog_group", "must be a non-empty string")
+		}
+		if o.Hidden != nil && !*o.Hidden && o.ChangelogGroup == nil && t.ChangelogGroup == nil {
+			add(path+".hidden", "type %q has no changelog_group to show", name)
+		}
+	}
+}
+
+// validateCommitlintRule checks the [level, applicable, value] shape commitlint expects.
+func validateCommitlintRule(rule CommitlintRule) []string {
+	if len(rule) == 0 {
+		return []string{"rule must be a non-empty array [level, applicable, value]"}
+	}
+	var msgs []string
+	level, ok := rule[0].(float64)
+	if !ok {
+		if i, isInt := rule[0].(int); isInt {
+			level, ok = float64(i), true
+		}
+	}
+	if !ok || (level != 0 && level != 1 && level != 2) {
+		msgs = append(msgs, fmt.Sprintf("level must be 0, 1 or 2, got %v", rule[0]))
+	}
+	if len(rule) > 1 {
+		if applicable, _ := rule[1].(string); applicable != "always" && applicable != "never" {
+			msgs = append(msgs, fmt.Sprintf(`applicable must be "always" or "never", got %v`, rule[1]))
+		}
+	}
+	return msgs
+}
+
+// jsonPath joins a parent path and a map key, quoting keys that would be ambiguous.
+func jsonPath(parent, key string) string {
+	if key != "" && !strings.ContainsAny(key, ".[]\" ") {
+		return parent + "." + key
+	}
+	return fmt.Sprintf("%s[%q]", parent, key)
+}
+
+// ValidateGeneratorNames reports entries in Config.Generators that do not
+// name a known generator.
+func (c *Config) ValidateGeneratorNames(known []string) []Problem {
+	var problems []Problem
+	names := make([]string, 0, len(c.Generators))
+	for name := range c.Generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !slices.Contains(known, name) {
+			problems = append(problems, Problem{
+				Path:    jsonPath("generators", name),
+				Message: fmt.Sprintf("unknown generator %q (available: %s)", name, strings.Join(known, ", ")),
+			})
+		}
+	}
+	return problems
+}
@@ -0,0 +1,272 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func problemPaths(problems []Problem) []string {
+	paths := make([]string, len(problems))
+	for i, p := range problems {
+		paths[i] = p.Path
+	}
+	return paths
+}
+
+func TestValidateValid(t *testing.T) {
+	added := "Added"
+	cfg := &Config{
+		Types: map[string]CommitType{
+			"feat":  {Description: "feature", ChangelogGroup: &added, Bump: "minor"},
+			"chore": {Description: "chore", Bump: "none"},
+		},
+		ExcludedScopes:  []string{"release", "deps/dev"},
+		CommitlintRules: map[string]CommitlintRule{"body-max-line-length": {float64(0), "always", float64(200)}},
+	}
+
+	if problems := cfg.Validate(); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+}
+
+func TestValidateInvalidBump(t *testing.T) {
+	cfg := &Config{
+		Types: map[string]CommitType{
+			"feat": {Description: "feature", Bump: "minr"},
+		},
+	}
+
+	problems := cfg.Validate()
+	if len(problems) != 1 {
+		t.Fatalf("expected 1 problem, got %v", problems)
+	}
+	if problems[0].Path != "types.feat.bump" {
+		t.Errorf("expected path types.feat.bump, got %q", problems[0].Path)
+	}
+	if !strings.Contains(problems[0].Message, "minr") {
+		t.Errorf("message should mention the bad value: %q", problems[0].Message)
+	}
+}
+
+func TestValidateTypeNames(t *testing.T) {
+	cfg := &Config{
+		Types: map[string]CommitType{
+			"":       {Description: "empty"},
+			"fe(at":  {Description: "regex metachar"},
+			"a.b":    {Description: "dot"},
+			"good-1": {Description: "fine"},
+		},
+	}
+
+	paths := problemPaths(cfg.Validate())
+	expected := []string{`types[""]`, `types["a.b"]`, `types.fe(at`}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestValidateEmptyChangelogGroup(t *testing.T) {
+	empty := "  "
+	cfg := &Config{
+		Types: map[string]CommitType{
+			"feat": {Description: "feature", ChangelogGroup: &empty},
+		},
+	}
+
+	paths := problemPaths(cfg.Validate())
+	if len(paths) != 1 || paths[0] != "types.feat.changelog_group" {
+		t.Errorf("expected types.feat.changelog_group problem, got %v", paths)
+	}
+}
+
+func TestValidateEmptyTypes(t *testing.T) {
+	cfg := &Config{Types: map[string]CommitType{}}
+
+	paths := problemPaths(cfg.Validate())
+	if len(paths) != 1 || paths[0] != "types" {
+		t.Errorf("expected types problem, got %v", paths)
+	}
+}
+
+func TestValidateExcludedScopes(t *testing.T) {
+	cfg := &Config{
+		Types:          map[string]CommitType{"feat": {Description: "feature"}},
+		ExcludedScopes: []string{"release", "", "a|b", "release"},
+	}
+
+	paths := problemPaths(cfg.Validate())
+	expected := []string{"excluded_scopes[1]", "excluded_scopes[2]", "excluded_scopes[3]"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	cfg := &Config{
+		Types: map[string]CommitType{"feat": {Description: "feature"}, "deps": {Description: "deps"}},
+		Scopes: map[string]Scope{
+			"deps":  {Types: []string{"deps", "chore"}},
+			"a b":   {Description: "space"},
+			"ok/go": {Description: "nested"},
+		},
+	}
+
+	paths := problemPaths(cfg.Validate())
+	expected := []string{`scopes["a b"]`, "scopes.deps.types[1]"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestValidateBreaking(t *testing.T) {
+	empty := ""
+	cfg := &Config{
+		Types: map[string]CommitType{"feat": {Description: "feature"}},
+		Breaking: &Breaking{
+			ChangelogGroup: &empty,
+			Bump:           "none",
+			Types:          []string{"feat", "nope"},
+		},
+	}
+
+	paths := problemPaths(cfg.Validate())
+	expected := []string{"breaking.changelog_group", "breaking.bump", "breaking.types[1]"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestValidateAliases(t *testing.T) {
+	cfg := &Config{
+		Types: map[string]CommitType{
+			"feat": {Description: "feature", Aliases: []Alias{{Name: "feature"}, {Name: "fix"}}},
+			"fix":  {Description: "fix", Aliases: []Alias{{Name: "bug fix"}, {Name: "feature"}}},
+		},
+	}
+
+	paths := problemPaths(cfg.Validate())
+	expected := []string{"types.feat.aliases[1]", "types.fix.aliases[0]", "types.fix.aliases[1]"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestValidateOrder(t *testing.T) {
+	cfg := &Config{
+		Types: map[string]CommitType{"feat": {Description: "feature"}, "fix": {Description: "fix"}},
+		Order: []string{"fix", "nope", "fix", "feat"},
+	}
+
+	paths := problemPaths(cfg.Validate())
+	expected := []string{"order[1]", "order[2]"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestValidateCommitlintRules(t *testing.T) {
+	cfg := &Config{
+		Types: map[string]CommitType{"feat": {Description: "feature"}},
+		CommitlintRules: map[string]CommitlintRule{
+			"empty":       {},
+			"bad-level":   {float64(3), "always"},
+			"bad-when":    {float64(2), "sometimes"},
+			"level-only":  {float64(0)},
+			"header-case": {float64(2), "never", "upper-case"},
+		},
+	}
+
+	paths := problemPaths(cfg.Validate())
+	expected := []string{"commitlint_rules.bad-level", "commitlint_rules.bad-when", "commitlint_rules.empty"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	err := &ValidationError{Problems: []Problem{
+		{Path: "types.feat.bump", Message: `invalid bump "minr"`},
+		{Path: "types", Message: "at least one commit type is required"},
+	}}
+
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "invalid config:") {
+		t.Errorf("unexpected message prefix: %q", msg)
+	}
+	if !strings.Contains(msg, `types.feat.bump: invalid bump "minr"`) {
+		t.Errorf("message should include each problem: %q", msg)
+	}
+}
+
+func TestValidateGenerators(t *testing.T) {
+	empty := ""
+	show := false
+	cfg := &Config{
+		Types: map[string]CommitType{"feat": {Description: "feature"}},
+		Generators: map[string]GeneratorConfig{
+			"changie": {Types: map[string]TypeOverride{
+				"feat": {Bump: "huge", ChangelogGroup: &empty},
+				"nope": {Bump: "minor"},
+			}},
+			"cliff": {Types: map[string]TypeOverride{"feat": {Hidden: &show}}},
+		},
+	}
+
+	paths := problemPaths(cfg.Validate())
+	expected := []string{"generators.changie.types.feat.bump", "generators.changie.types.feat.changelog_group", "generators.changie.types.nope", "generators.cliff.types.feat.hidden"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestValidateGeneratorNames(t *testing.T) {
+	cfg := &Config{
+		Types:      map[string]CommitType{"feat": {Description: "feature"}},
+		Generators: map[string]GeneratorConfig{"cliff": {}, "clif": {}},
+	}
+
+	paths := problemPaths(cfg.ValidateGeneratorNames([]string{"changie", "cliff"}))
+	if strings.Join(paths, ",") != "generators.clif" {
+		t.Errorf("expected [generators.clif], got %v", paths)
+	}
+}
+
+func TestValidatePackages(t *testing.T) {
+	cfg := &Config{
+		Types: map[string]CommitType{"feat": {Description: "feature"}},
+		Packages: map[string]Package{
+			".":             {},
+			"../outside":    {},
+			"packages/api/": {},
+			"packages/web": {
+				ExcludedScopes: []string{"ui", "ui"},
+				Types:          map[string]TypeOverride{"nope": {}},
+			},
+		},
+	}
+
+	paths := problemPaths(cfg.Validate())
+	expected := []string{
+		`packages["."].name`,
+		`packages["../outside"]`,
+		"packages.packages/api/",
+		"packages.packages/web.excluded_scopes[1]",
+		"packages.packages/web.types.nope",
+	}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestValidateDependencies(t *testing.T) {
+	cfg := &Config{
+		Types:        map[string]CommitType{"feat": {Description: "feature"}},
+		Dependencies: &Dependencies{Type: "deps", Scope: "a b"},
+	}
+
+	paths := problemPaths(cfg.Validate())
+	expected := []string{"dependencies.type", "dependencies.scope"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+}
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"fmt"
+	"path"
 
 	"github.com/tylerbutler/commit-config-gen/internal/config"
 	"gopkg.in/yaml.v3"
@@ -17,13 +18,30 @@ type ChangieGenerator struct{}
 func (g *ChangieGenerator) Name() string     { return "changie" }
 func (g *ChangieGenerator) FileName() string { return ".changie.yaml" }
 
+// Locations returns both extensions changie reads.
+func (g *ChangieGenerator) Locations() []Location {
+	return []Location{
+		{Path: ".changie.yaml", Format: formatYAML},
+		{Path: ".changie.yml", Format: formatYAML},
+	}
+}
+
 func (g *ChangieGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
 	kinds := buildChangieKinds(cfg)
+	projects := buildChangieProjects(cfg)
 
+	var out []byte
+	var err error
 	if existing != nil {
-		return mergeChangie(existing, kinds)
+		out, err = mergeChangie(existing, kinds, projects)
+	} else {
+		out, err = freshChangie(kinds, projects)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return freshChangie(kinds)
+	return applyExtras(out, formatYAML, cfg.GeneratorExtra(g.Name()))
 }
 
 type changieKind struct {
@@ -33,6 +51,9 @@ type changieKind struct {
 
 func buildChangieKinds(cfg *config.Config) []changieKind {
 	var kinds []changieKind
+	if cfg.Breaking != nil && cfg.Breaking.ChangelogGroup != nil {
+		kinds = append(kinds, changieKind{Label: *cfg.Breaking.ChangelogGroup, Auto: cfg.Breaking.BumpLevel()})
+	}
 	for _, name := range cfg.TypeNames() {
 		t := cfg.Types[name]
 		if t.ChangelogGroup == nil {
@@ -47,10 +68,35 @@ func buildChangieKinds(cfg *config.Config) []changieKind {
 	return kinds
 }
 
-func freshChangie(kinds []changieKind) ([]byte, error) {
+type changieProject struct {
+	Label         string `yaml:"label"`
+	Key           string `yaml:"key"`
+	ChangelogPath string `yaml:"changelogPath"`
+}
+
+// buildChangieProjects returns one project per configured package. changie's
+// kinds are shared by every project, so per-package types and excluded
+// scopes can't be applied.
+func buildChangieProjects(cfg *config.Config) []changieProject {
+	var projects []changieProject
+	for _, p := range cfg.PackagePaths() {
+		name := cfg.PackageName(p)
+		projects = append(projects, changieProject{
+			Label:         name,
+			Key:           name,
+			ChangelogPath: path.Join(p, "CHANGELOG.md"),
+		})
+	}
+	return projects
+}
+
+func freshChangie(kinds []changieKind, projects []changieProject) ([]byte, error) {
 	doc := map[string]any{
 		"kinds": kinds,
 	}
+	if len(projects) > 0 {
+		doc["projects"] = projects
+	}
 	data, err := yaml.Marshal(doc)
 	if err != nil {
 		return nil, err
@@ -58,7 +104,7 @@ func freshChangie(kinds []changieKind) ([]byte, error) {
 	return data, nil
 }
 
-func mergeChangie(existing []byte, kinds []changieKind) ([]byte, error) {
+func mergeChangie(existing []byte, kinds []changieKind, projects []changieProject) ([]byte, error) {
 	var root yaml.Node
 	if err := yaml.Unmarshal(existing, &root); err != nil {
 		return nil, fmt.Errorf("parsing existing .changie.yaml: %w", err)
@@ -66,54 +112,21 @@ func mergeChangie(existing []byte, kinds []changieKind) ([]byte, error) {
 
 	// root is a Document node; its first child is the mapping
 	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
-		return freshChangie(kinds)
+		return freshChangie(kinds, projects)
 	}
 
 	mapping := root.Content[0]
 	if mapping.Kind != yaml.MappingNode {
-		return freshChangie(kinds)
+		return freshChangie(kinds, projects)
 	}
 
-	// Find and replace the "kinds" key
-	found := false
-	for i := 0; i < len(mapping.Content)-1; i += 2 {
-		if mapping.Content[i].Value == "kinds" {
-			// Marshal kinds to a YAML node
-			var kindsNode yaml.Node
-			kindsBytes, err := yaml.Marshal(kinds)
-			if err != nil {
-				return nil, err
-			}
-			if err := yaml.Unmarshal(kindsBytes, &kindsNode); err != nil {
-				return nil, err
-			}
-			// kindsNode is Document -> the sequence
-			if kindsNode.Kind == yaml.DocumentNode && len(kindsNode.Content) > 0 {
-				mapping.Content[i+1] = kindsNode.Content[0]
-			}
-			found = true
-			break
-		}
+	if err := setYAMLKey(mapping, "kinds", kinds); err != nil {
+		return nil, err
 	}
-
-	if !found {
-		// Append kinds key-value pair
-		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: "kinds", Tag: "!!str"}
-		var kindsNode yaml.Node
-		kindsBytes, err := yaml.Marshal(kinds)
-		if err != nil {
-			return nil, err
-		}
-		if err := yaml.Unmarshal(kindsBytes, &kindsNode); err != nil {
+	if len(projects) > 0 {
+		if err := setYAMLKey(mapping, "projects", projects); err != nil {
 			return nil, err
 		}
-		var valNode *yaml.Node
-		if kindsNode.Kind == yaml.DocumentNode && len(kindsNode.Content) > 0 {
-			valNode = kindsNode.Content[0]
-		} else {
-			valNode = &kindsNode
-		}
-		mapping.Content = append(mapping.Content, keyNode, valNode)
 	}
 
 	data, err := yaml.Marshal(&root)
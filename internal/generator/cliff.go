@@ -1,11 +1,9 @@
 package generator
 
 import (
-	"bytes"
 	"fmt"
 	"strings"
 
-	toml "github.com/pelletier/go-toml/v2"
 	"github.com/tylerbutler/commit-config-gen/internal/config"
 )
 
@@ -19,25 +17,73 @@ type CliffGenerator struct{}
 func (g *CliffGenerator) Name() string     { return "cliff" }
 func (g *CliffGenerator) FileName() string { return "cliff.toml" }
 
+// Locations returns cliff.toml and the manifest tables git-cliff also reads.
+func (g *CliffGenerator) Locations() []Location {
+	return []Location{
+		{Path: "cliff.toml", Format: formatTOML},
+		{Path: "Cargo.toml", Format: formatTOML, Section: []string{"workspace", "metadata", "git-cliff"}},
+		{Path: "Cargo.toml", Format: formatTOML, Section: []string{"package", "metadata", "git-cliff"}},
+		{Path: "pyproject.toml", Format: formatTOML, Section: []string{"tool", "git-cliff"}},
+	}
+}
+
 func (g *CliffGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
 	parsers := buildCommitParsers(cfg)
+	protect := cfg.Breaking != nil
 
+	var out []byte
+	var err error
 	if existing != nil {
-		return mergeCliff(existing, parsers)
+		out, err = mergeCliff(existing, parsers, protect)
+	} else {
+		out, err = freshCliff(cfg, parsers, protect)
 	}
-	return freshCliff(cfg, parsers)
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatTOML, cfg.GeneratorExtra(g.Name()))
 }
 
 type commitParser struct {
-	Message string `toml:"message"`
+	Message string `toml:"message,omitempty"`
+	Footer  string `toml:"footer,omitempty"`
 	Group   string `toml:"group,omitempty"`
 	Skip    bool   `toml:"skip,omitempty"`
 }
 
+// breakingPatterns returns the message and footer regexes that identify
+// breaking commits. The message pattern only matches types allowed to use "!".
+func breakingPatterns(cfg *config.Config) (message, footer string) {
+	typePattern := "[a-z]+"
+	if cfg.Breaking != nil && cfg.Breaking.Types != nil {
+		var allowed []string
+		for _, name := range cfg.TypeNames() {
+			if cfg.Breaking.AllowsBang(name) {
+				allowed = append(allowed, name)
+				for _, alias := range cfg.Types[name].Aliases {
+					allowed = append(allowed, alias.Name)
+				}
+			}
+		}
+		typePattern = "(" + strings.Join(allowed, "|") + ")"
+	}
+	return "^" + typePattern + `(\(.*\))?!:`, "^BREAKING[ -]CHANGE"
+}
+
 func buildCommitParsers(cfg *config.Config) []commitParser {
 	var parsers []commitParser
 
-	for _, scope := range cfg.ExcludedScopes {
+	if cfg.Breaking != nil && cfg.Breaking.ChangelogGroup != nil {
+		message, footer := breakingPatterns(cfg)
+		group := *cfg.Breaking.ChangelogGroup
+		if cfg.Breaking.Types == nil || len(cfg.Breaking.Types) > 0 {
+			parsers = append(parsers, commitParser{Message: message, Group: group})
+		}
+		parsers = append(parsers, commitParser{Footer: footer, Group: group})
+	}
+
+	for _, scope := range cfg.HiddenScopes() {
 		parsers = append(parsers, commitParser{
 			Message: fmt.Sprintf(`^[a-z]+\(%s\)`, scope),
 			Skip:    true,
@@ -51,6 +97,12 @@ func buildCommitParsers(cfg *config.Config) []commitParser {
 				Message: "^" + name,
 				Group:   *t.ChangelogGroup,
 			})
+			for _, alias := range t.Aliases {
+				parsers = append(parsers, commitParser{
+					Message: "^" + alias.Name,
+					Group:   *t.ChangelogGroup,
+				})
+			}
 		}
 	}
 
@@ -62,39 +114,23 @@ func buildCommitParsers(cfg *config.Config) []commitParser {
 	return parsers
 }
 
-func mergeCliff(existing []byte, parsers []commitParser) ([]byte, error) {
-	var doc map[string]any
-	if err := toml.Unmarshal(existing, &doc); err != nil {
+// mergeCliff replaces git.commit_parsers in place. The rest of the file,
+// comments included, is left as it was. Parsers written as [[git.commit_parsers]]
+// tables are replaced by the inline array.
+func mergeCliff(existing []byte, parsers []commitParser, protect bool) ([]byte, error) {
+	out, err := deleteTOMLArrayTables(existing, []string{"git", "commit_parsers"})
+	if err != nil {
 		return nil, fmt.Errorf("parsing existing cliff.toml: %w", err)
 	}
-
-	git, ok := doc["git"].(map[string]any)
-	if !ok {
-		git = map[string]any{}
-		doc["git"] = git
-	}
-
-	// Convert parsers to []any for TOML serialization
-	var parserMaps []any
-	for _, p := range parsers {
-		m := map[string]any{"message": p.Message}
-		if p.Group != "" {
-			m["group"] = p.Group
-		}
-		if p.Skip {
-			m["skip"] = p.Skip
+	if protect {
+		if out, err = setTOMLValue(out, []string{"git"}, "protect_breaking_commits", "true"); err != nil {
+			return nil, fmt.Errorf("parsing existing cliff.toml: %w", err)
 		}
-		parserMaps = append(parserMaps, m)
 	}
-	git["commit_parsers"] = parserMaps
-
-	var buf bytes.Buffer
-	enc := toml.NewEncoder(&buf)
-	enc.SetIndentTables(true)
-	if err := enc.Encode(doc); err != nil {
-		return nil, fmt.Errorf("encoding cliff.toml: %w", err)
+	if out, err = setTOMLValue(out, []string{"git"}, "commit_parsers", tomlParserArray(parsers)); err != nil {
+		return nil, fmt.Errorf("parsing existing cliff.toml: %w", err)
 	}
-	return buf.Bytes(), nil
+	return out, nil
 }
 
 const cliffHeader = `# git-cliff config
@@ -133,24 +169,16 @@ trim = false
 conventional_commits = true
 filter_unconventional = true
 tag_pattern = "v[0-9].*"
-commit_parsers = [
 `
 
-func freshCliff(cfg *config.Config, parsers []commitParser) ([]byte, error) {
+func freshCliff(cfg *config.Config, parsers []commitParser, protect bool) ([]byte, error) {
 	var sb strings.Builder
 
 	sb.WriteString(cliffHeader)
-
-	for _, p := range parsers {
-		if p.Skip {
-			sb.WriteString(fmt.Sprintf(`    { message = '%s', skip = true },`, p.Message))
-		} else {
-			sb.WriteString(fmt.Sprintf(`    { message = '%s', group = '%s' },`, p.Message, p.Group))
-		}
-		sb.WriteString("\n")
+	if protect {
+		sb.WriteString("protect_breaking_commits = true\n")
 	}
-
-	sb.WriteString("]\n")
+	sb.WriteString("commit_parsers = " + tomlParserArray(parsers) + "\n")
 
 	return []byte(sb.String()), nil
 }
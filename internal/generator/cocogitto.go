@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	toml "github.com/pelletier/go-toml/v2"
+	"github.com/tylerbutler/commit-config-gen/internal/config"
+)
+
+func init() {
+	Register(&CocogittoGenerator{})
+}
+
+// CocogittoGenerator generates cog.toml for cocogitto.
+type CocogittoGenerator struct{}
+
+func (g *CocogittoGenerator) Name() string     { return "cocogitto" }
+func (g *CocogittoGenerator) FileName() string { return "cog.toml" }
+
+func (g *CocogittoGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
+	types := buildCogCommitTypes(cfg)
+
+	var out []byte
+	var err error
+	if existing != nil {
+		out, err = mergeCocogitto(existing, types)
+	} else {
+		out, err = freshCocogitto(types)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatTOML, cfg.GeneratorExtra(g.Name()))
+}
+
+// buildCogCommitTypes returns the [commit_types] table. Aliases get their own
+// entries so older commits still parse. cocogitto has no per-type major bump;
+// breaking changes always bump major.
+func buildCogCommitTypes(cfg *config.Config) map[string]any {
+	types := map[string]any{}
+	for _, name := range cfg.TypeNames() {
+		t := cfg.Types[name]
+		entry := map[string]any{}
+		if t.ChangelogGroup != nil {
+			entry["changelog_title"] = *t.ChangelogGroup
+		} else {
+			// cocogitto requires a title even for omitted types
+			entry["changelog_title"] = t.Description
+			entry["omit_from_changelog"] = true
+		}
+		switch t.Bump {
+		case "minor":
+			entry["bump_minor"] = true
+		case "patch":
+			entry["bump_patch"] = true
+		}
+
+		types[name] = entry
+		for _, alias := range t.Aliases {
+			types[alias.Name] = entry
+		}
+	}
+	return types
+}
+
+// freshCocogitto writes the generated table into an empty file, so fresh
+// output has the same layout as a merge.
+func freshCocogitto(types map[string]any) ([]byte, error) {
+	return mergeCocogitto([]byte{}, types)
+}
+
+// mergeCocogitto replaces the [commit_types] tables where they were, or adds
+// them at the end. The rest of cog.toml, comments included, is left as it was.
+func mergeCocogitto(existing []byte, types map[string]any) ([]byte, error) {
+	table := []string{"commit_types"}
+	sections, err := parseTOMLTable(existing, table, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing existing cog.toml: %w", err)
+	}
+	at := -1
+	for _, s := range sections {
+		if len(s.key) >= len(table) && slices.Equal(s.key[:len(table)], table) {
+			at = s.start
+			break
+		}
+	}
+	out, err := deleteTOMLTables(existing, table)
+	if err != nil {
+		return nil, fmt.Errorf("parsing existing cog.toml: %w", err)
+	}
+
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.SetIndentTables(true)
+	if err := enc.Encode(map[string]any{"commit_types": types}); err != nil {
+		return nil, fmt.Errorf("encoding cog.toml: %w", err)
+	}
+	block := buf.Bytes()
+
+	if at < 0 || at >= len(out) {
+		if len(out) > 0 {
+			out = slices.Concat(bytes.TrimRight(out, "\n"), []byte("\n\n"))
+		}
+		return slices.Concat(out, block), nil
+	}
+	return slices.Concat(out[:at], block, []byte("\n"), out[at:]), nil
+}
@@ -0,0 +1,214 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+	"github.com/tylerbutler/commit-config-gen/internal/config"
+)
+
+func init() {
+	Register(&CommitizenGenerator{})
+}
+
+// CommitizenGenerator generates commitizen's cz_customize rules, in .cz.toml or
+// in the [tool.commitizen] table of pyproject.toml when there is one.
+type CommitizenGenerator struct{}
+
+func (g *CommitizenGenerator) Name() string     { return "commitizen" }
+func (g *CommitizenGenerator) FileName() string { return ".cz.toml" }
+
+// Locations returns pyproject.toml when it has a [tool.commitizen] table, then
+// the standalone files commitizen reads.
+func (g *CommitizenGenerator) Locations() []Location {
+	return []Location{
+		{Path: "pyproject.toml", Format: formatTOML, Key: []string{"tool", "commitizen"}},
+		{Path: ".cz.toml", Format: formatTOML},
+		{Path: "cz.toml", Format: formatTOML},
+	}
+}
+
+func (g *CommitizenGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
+	customize := buildCommitizenCustomize(cfg)
+
+	var out []byte
+	var err error
+	if existing != nil {
+		out, err = mergeCommitizen(existing, customize)
+	} else {
+		out, err = freshCommitizen(customize)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatTOML, cfg.GeneratorExtra(g.Name()))
+}
+
+// commitizenBumpPattern is commitizen's conventional-commits bump pattern; its
+// first group is matched against the bump_map keys.
+const commitizenBumpPattern = `^((BREAKING[\-\ ]CHANGE|\w+)(\(.+\))?!?):`
+
+func buildCommitizenCustomize(cfg *config.Config) map[string]any {
+	var allowed, visible []string
+	bumpMap := map[string]any{}
+	changeTypeMap := map[string]any{}
+	var order []string
+	var choices []any
+
+	breakingBump := strings.ToUpper(cfg.Breaking.BumpLevel())
+	bumpMap[`^.+!$`] = breakingBump
+	bumpMap[`^BREAKING[\-\ ]CHANGE`] = breakingBump
+	if cfg.Breaking != nil && cfg.Breaking.ChangelogGroup != nil {
+		changeTypeMap["BREAKING CHANGE"] = *cfg.Breaking.ChangelogGroup
+		order = append(order, *cfg.Breaking.ChangelogGroup)
+	}
+
+	for _, name := range cfg.TypeNames() {
+		t := cfg.Types[name]
+		names := []string{name}
+		for _, alias := range t.Aliases {
+			names = append(names, alias.Name)
+		}
+
+		allowed = append(allowed, name)
+		for _, alias := range t.Aliases {
+			if alias.Allow {
+				allowed = append(allowed, alias.Name)
+			}
+		}
+		choices = append(choices, map[string]any{
+			"value": name,
+			"name":  fmt.Sprintf("%s: %s", name, t.Description),
+		})
+
+		for _, n := range names {
+			if t.Bump != "" && t.Bump != "none" {
+				bumpMap[`^`+regexp.QuoteMeta(n)+`\b`] = strings.ToUpper(t.Bump)
+			}
+			if t.ChangelogGroup != nil {
+				visible = append(visible, n)
+				changeTypeMap[n] = *t.ChangelogGroup
+			}
+		}
+		if t.ChangelogGroup != nil && !slices.Contains(order, *t.ChangelogGroup) {
+			order = append(order, *t.ChangelogGroup)
+		}
+	}
+
+	bang := "!?"
+	if cfg.Breaking != nil && cfg.Breaking.Types != nil && len(cfg.Breaking.Types) == 0 {
+		bang = ""
+	}
+	allowedPattern := strings.Join(allowed, "|")
+	visiblePattern := strings.Join(visible, "|")
+
+	scopeQuestion := map[string]any{
+		"type":    "input",
+		"name":    "scope",
+		"message": "Scope (press enter to skip)",
+	}
+	if len(cfg.Scopes) > 0 {
+		scopeChoices := []any{map[string]any{"value": "", "name": "(none)"}}
+		for _, scope := range cfg.ScopeNames() {
+			label := scope
+			if d := cfg.Scopes[scope].Description; d != "" {
+				label = fmt.Sprintf("%s: %s", scope, d)
+			}
+			scopeChoices = append(scopeChoices, map[string]any{"value": scope, "name": label})
+		}
+		scopeQuestion = map[string]any{
+			"type":    "list",
+			"name":    "scope",
+			"message": "Select the scope of this change",
+			"choices": scopeChoices,
+		}
+	}
+
+	var example string
+	if names := cfg.TypeNames(); len(names) > 0 {
+		example = names[0] + ": describe the change"
+	}
+
+	return map[string]any{
+		"message_template":  "{{change_type}}{% if scope %}({{scope}}){% endif %}: {{subject}}",
+		"example":           example,
+		"schema":            "<type>(<scope>): <subject>",
+		"schema_pattern":    `^(` + allowedPattern + `)(\(\S+\))?` + bang + `:\s.+`,
+		"bump_pattern":      commitizenBumpPattern,
+		"bump_map":          bumpMap,
+		"change_type_map":   changeTypeMap,
+		"change_type_order": order,
+		"changelog_pattern": `^(` + visiblePattern + `)(\(.+\))?!?:`,
+		"commit_parser":     `^(?P<change_type>` + visiblePattern + `)(?:\((?P<scope>[^()\r\n]*)\)|\()?(?P<breaking>!)?:\s(?P<message>.*)?`,
+		"questions": []any{
+			map[string]any{
+				"type":    "list",
+				"name":    "change_type",
+				"message": "Select the type of change you are committing",
+				"choices": choices,
+			},
+			scopeQuestion,
+			map[string]any{
+				"type":    "input",
+				"name":    "subject",
+				"message": "Write a short summary of the change",
+			},
+		},
+	}
+}
+
+// freshCommitizen writes the generated settings into an empty file, so fresh
+// output has the same layout as a merge.
+func freshCommitizen(customize map[string]any) ([]byte, error) {
+	return mergeCommitizen([]byte{}, customize)
+}
+
+// mergeCommitizen sets name and replaces the [tool.commitizen.customize]
+// tables. Other commitizen settings (such as version) and every other table
+// are left as they were, comments included.
+func mergeCommitizen(existing []byte, customize map[string]any) ([]byte, error) {
+	table := []string{"tool", "commitizen"}
+	out, err := setTOMLValue(existing, table, "name", tomlString("cz_customize"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing existing commitizen config: %w", err)
+	}
+	if out, err = deleteTOMLTables(out, slices.Concat(table, []string{"customize"})); err != nil {
+		return nil, fmt.Errorf("parsing existing commitizen config: %w", err)
+	}
+	// setTOMLValue has checked [tool.commitizen] isn't written as dotted keys,
+	// but a customize key in it would clash with the tables added below.
+	sections, err := parseTOMLTable(out, slices.Concat(table, []string{"customize"}), false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing existing commitizen config: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]any{
+		"tool": map[string]any{"commitizen": map[string]any{"customize": customize}},
+	}); err != nil {
+		return nil, fmt.Errorf("encoding commitizen config: %w", err)
+	}
+	block := buf.Bytes()
+	block = block[bytes.Index(block, []byte("[tool.commitizen.customize]")):]
+
+	// Add the tables after the last [tool.commitizen...] table.
+	at := 0
+	for _, s := range sections {
+		if len(s.key) >= len(table) && slices.Equal(s.key[:len(table)], table) {
+			at = s.end
+		}
+	}
+	text := slices.Concat([]byte("\n"), block)
+	if at > 0 && out[at-1] != '\n' {
+		text = slices.Concat([]byte("\n"), text)
+	}
+	if at < len(out) && out[at] != '\n' {
+		text = append(text, '\n')
+	}
+	return slices.Concat(out[:at], text, out[at:]), nil
+}
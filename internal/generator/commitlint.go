@@ -1,7 +1,6 @@
 package generator
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/tylerbutler/commit-config-gen/internal/config"
@@ -17,52 +16,164 @@ type CommitlintGenerator struct{}
 func (g *CommitlintGenerator) Name() string     { return "commitlint" }
 func (g *CommitlintGenerator) FileName() string { return ".commitlintrc.json" }
 
+// Locations returns the files and package.json key commitlint reads.
+func (g *CommitlintGenerator) Locations() []Location {
+	return []Location{
+		{Path: ".commitlintrc.json", Format: formatJSON},
+		{Path: ".commitlintrc"},
+		{Path: ".commitlintrc.yaml", Format: formatYAML},
+		{Path: ".commitlintrc.yml", Format: formatYAML},
+		{Path: "package.json", Format: formatJSON, Section: []string{"commitlint"}},
+	}
+}
+
 func (g *CommitlintGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
-	typeNames := cfg.TypeNames()
+	cfg = cfg.ForGenerator(g.Name())
+	var typeNames []string
+	for _, name := range cfg.TypeNames() {
+		typeNames = append(typeNames, name)
+		for _, alias := range cfg.Types[name].Aliases {
+			if alias.Allow {
+				typeNames = append(typeNames, alias.Name)
+			}
+		}
+	}
 
 	rules := map[string]any{
 		"type-enum": []any{2, "always", typeNames},
 	}
+	if len(cfg.Scopes) > 0 {
+		rules["scope-enum"] = []any{2, "always", cfg.ScopeNames()}
+	}
+	// commitlint can't restrict "!" per type, only forbid it outright
+	if cfg.Breaking != nil && cfg.Breaking.Types != nil && len(cfg.Breaking.Types) == 0 {
+		rules["subject-exclamation-mark"] = []any{2, "never"}
+	}
 	for name, rule := range cfg.CommitlintRules {
 		rules[name] = rule
 	}
 
+	prompt := buildCommitlintPrompt(cfg)
+
+	var out []byte
+	var err error
 	if existing != nil {
-		return mergeCommitlint(existing, rules)
+		out, err = mergeCommitlint(existing, rules, prompt)
+	} else {
+		out, err = freshCommitlint(rules, prompt)
 	}
-	return freshCommitlint(rules)
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatJSON, cfg.GeneratorExtra(g.Name()))
+}
+
+// commitlintPrompt holds the generated parts of the "prompt" section read by
+// @commitlint/prompt, @commitlint/cz-commitlint and cz-git.
+type commitlintPrompt struct {
+	TypeEnum       *orderedMap // prompt.questions.type.enum
+	Scopes         []any       // prompt.scopes (cz-git); nil when no scopes are configured
+	ScopeOverrides *orderedMap // prompt.scopeOverrides (cz-git); nil when no scope limits its types
 }
 
-func freshCommitlint(rules map[string]any) ([]byte, error) {
+func buildCommitlintPrompt(cfg *config.Config) commitlintPrompt {
+	var prompt commitlintPrompt
+	prompt.TypeEnum = &orderedMap{}
+	for _, name := range cfg.TypeNames() {
+		t := cfg.Types[name]
+		entry := map[string]any{"description": t.Description}
+		if t.ChangelogGroup != nil {
+			entry["title"] = *t.ChangelogGroup
+		}
+		if t.Emoji != "" {
+			entry["emoji"] = t.Emoji
+		}
+		prompt.TypeEnum.Set(name, entry)
+	}
+
+	if len(cfg.Scopes) > 0 {
+		scopeNames := cfg.ScopeNames()
+		limited := false
+		for _, scope := range scopeNames {
+			name := scope
+			if d := cfg.Scopes[scope].Description; d != "" {
+				name = fmt.Sprintf("%s: %s", scope, d)
+			}
+			prompt.Scopes = append(prompt.Scopes, map[string]any{"value": scope, "name": name})
+			limited = limited || len(cfg.Scopes[scope].Types) > 0
+		}
+		// cz-git offers scopeOverrides[type] instead of scopes for that type,
+		// so list the allowed scopes for every type that can't use them all.
+		if limited {
+			prompt.ScopeOverrides = &orderedMap{}
+			for _, name := range cfg.TypeNames() {
+				allowed := []any{}
+				for i, scope := range scopeNames {
+					if cfg.Scopes[scope].AllowsType(name) {
+						allowed = append(allowed, prompt.Scopes[i])
+					}
+				}
+				if len(allowed) < len(prompt.Scopes) {
+					prompt.ScopeOverrides.Set(name, allowed)
+				}
+			}
+		}
+	}
+	return prompt
+}
+
+func freshCommitlint(rules map[string]any, prompt commitlintPrompt) ([]byte, error) {
 	doc := map[string]any{
 		"extends": []string{"@commitlint/config-conventional"},
 		"rules":   rules,
+		"prompt":  applyCommitlintPrompt(&orderedMap{}, prompt),
 	}
 	return marshalJSON(doc)
 }
 
-func mergeCommitlint(existing []byte, rules map[string]any) ([]byte, error) {
-	var doc map[string]any
-	if err := json.Unmarshal(existing, &doc); err != nil {
-		return nil, fmt.Errorf("parsing existing .commitlintrc.json: %w", err)
-	}
-
-	existingRules, ok := doc["rules"].(map[string]any)
+// applyCommitlintPrompt writes the generated prompt settings into an existing
+// "prompt" object, keeping any other questions and options. Scopes left over
+// from an earlier run are removed.
+func applyCommitlintPrompt(existing *orderedMap, prompt commitlintPrompt) *orderedMap {
+	questions := existing.Object("questions")
+	typeQuestion, ok := questions.values["type"].(*orderedMap)
 	if !ok {
-		existingRules = map[string]any{}
+		typeQuestion = &orderedMap{}
+		typeQuestion.Set("description", "Select the type of change that you're committing")
+		questions.Set("type", typeQuestion)
 	}
-	for k, v := range rules {
-		existingRules[k] = v
+	typeQuestion.Set("enum", prompt.TypeEnum)
+	if prompt.Scopes != nil {
+		existing.Set("scopes", prompt.Scopes)
+	} else {
+		existing.Delete("scopes")
 	}
-	doc["rules"] = existingRules
-
-	return marshalJSON(doc)
+	if prompt.ScopeOverrides != nil {
+		existing.Set("scopeOverrides", prompt.ScopeOverrides)
+	} else {
+		existing.Delete("scopeOverrides")
+	}
+	return existing
 }
 
-func marshalJSON(v any) ([]byte, error) {
-	data, err := json.MarshalIndent(v, "", "  ")
+// commitlintManagedRules are the rules Generate adds only under some settings;
+// a merge removes them when it doesn't add them.
+var commitlintManagedRules = []string{"scope-enum", "subject-exclamation-mark"}
+
+func mergeCommitlint(existing []byte, rules map[string]any, prompt commitlintPrompt) ([]byte, error) {
+	doc, err := parseJSONObject(existing)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("parsing existing .commitlintrc.json: %w", err)
 	}
-	return append(data, '\n'), nil
+
+	existingRules := doc.Object("rules")
+	for _, name := range commitlintManagedRules {
+		if _, ok := rules[name]; !ok {
+			existingRules.Delete(name)
+		}
+	}
+	existingRules.SetAll(rules)
+	applyCommitlintPrompt(doc.Object("prompt"), prompt)
+
+	return marshalJSONIndent(doc, jsonIndent(existing))
 }
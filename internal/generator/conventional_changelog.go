@@ -1,7 +1,6 @@
 package generator
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/tylerbutler/commit-config-gen/internal/config"
@@ -17,13 +16,30 @@ type ConventionalChangelogGenerator struct{}
 func (g *ConventionalChangelogGenerator) Name() string     { return "conventional-changelog" }
 func (g *ConventionalChangelogGenerator) FileName() string { return ".versionrc.json" }
 
+// Locations returns the files and package.json key standard-version reads.
+func (g *ConventionalChangelogGenerator) Locations() []Location {
+	return []Location{
+		{Path: ".versionrc.json", Format: formatJSON},
+		{Path: ".versionrc", Format: formatJSON},
+		{Path: "package.json", Format: formatJSON, Section: []string{"standard-version"}},
+	}
+}
+
 func (g *ConventionalChangelogGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
 	types := buildVersionRCTypes(cfg)
 
+	var out []byte
+	var err error
 	if existing != nil {
-		return mergeVersionRC(existing, types)
+		out, err = mergeVersionRC(existing, types)
+	} else {
+		out, err = freshVersionRC(types)
 	}
-	return freshVersionRC(types)
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatJSON, cfg.GeneratorExtra(g.Name()))
 }
 
 type versionRCType struct {
@@ -55,10 +71,10 @@ func freshVersionRC(types []versionRCType) ([]byte, error) {
 }
 
 func mergeVersionRC(existing []byte, types []versionRCType) ([]byte, error) {
-	var doc map[string]any
-	if err := json.Unmarshal(existing, &doc); err != nil {
+	doc, err := parseJSONObject(existing)
+	if err != nil {
 		return nil, fmt.Errorf("parsing existing .versionrc.json: %w", err)
 	}
-	doc["types"] = types
-	return marshalJSON(doc)
+	doc.Set("types", types)
+	return marshalJSONIndent(doc, jsonIndent(existing))
 }
@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/tylerbutler/commit-config-gen/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	Register(&DependabotGenerator{})
+}
+
+// DependabotGenerator sets the commit-message prefix of every update in
+// .github/dependabot.yml.
+type DependabotGenerator struct{}
+
+func (g *DependabotGenerator) Name() string     { return "dependabot" }
+func (g *DependabotGenerator) FileName() string { return ".github/dependabot.yml" }
+
+// Locations returns both extensions Dependabot reads.
+func (g *DependabotGenerator) Locations() []Location {
+	return []Location{
+		{Path: ".github/dependabot.yml", Format: formatYAML},
+		{Path: ".github/dependabot.yaml", Format: formatYAML},
+	}
+}
+
+func (g *DependabotGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
+	typ, err := dependencyType(cfg)
+	if err != nil {
+		return nil, err
+	}
+	prefix := typ
+	if scope := cfg.DependencyScope(); scope != "" {
+		prefix = fmt.Sprintf("%s(%s)", typ, scope)
+	}
+
+	// An empty config would update nothing; entries are added by hand.
+	if existing == nil {
+		return nil, &SkipError{Reason: "no existing file; create one with your updates entries to opt in"}
+	}
+	out, err := mergeDependabot(existing, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatYAML, cfg.GeneratorExtra(g.Name()))
+}
+
+// mergeDependabot sets commit-message.prefix on every entry of updates. Only
+// the prefixes it changes are rewritten, so the rest of the file keeps its
+// comments, blank lines and quoting.
+func mergeDependabot(existing []byte, prefix string) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(existing, &root); err != nil {
+		return nil, fmt.Errorf("parsing existing dependabot.yml: %w", err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return existing, nil
+	}
+	if root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parsing existing dependabot.yml: expected a mapping at the top level")
+	}
+	updates := findYAMLKey(root.Content[0], "updates")
+	if updates == nil || updates.Kind != yaml.SequenceNode {
+		return existing, nil
+	}
+
+	var edits []textEdit
+	for _, update := range updates.Content {
+		if update.Kind != yaml.MappingNode {
+			continue
+		}
+		commitMessage := findYAMLKey(update, "commit-message")
+		if commitMessage != nil && commitMessage.Kind == yaml.MappingNode && commitMessage.Style&yaml.FlowStyle == 0 && len(commitMessage.Content) > 0 {
+			value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: prefix}
+			if old := findYAMLKey(commitMessage, "prefix"); old != nil {
+				if old.Kind == yaml.ScalarNode && old.Value == prefix {
+					continue
+				}
+				value.Style = old.Style
+			}
+			edit, err := setYAMLEntry(existing, commitMessage, "prefix", value)
+			if err != nil {
+				return nil, fmt.Errorf("updating dependabot.yml: %w", err)
+			}
+			edits = append(edits, edit)
+			continue
+		}
+
+		// No block commit-message to edit: write the whole entry.
+		if commitMessage == nil || commitMessage.Kind != yaml.MappingNode {
+			commitMessage = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		if err := setYAMLKey(commitMessage, "prefix", prefix); err != nil {
+			return nil, err
+		}
+		commitMessage.Style = 0
+		edit, err := setYAMLEntry(existing, update, "commit-message", commitMessage)
+		if err != nil {
+			return nil, fmt.Errorf("updating dependabot.yml: %w", err)
+		}
+		edits = append(edits, edit)
+	}
+	return applyTextEdits(existing, edits), nil
+}
@@ -0,0 +1,339 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// embedding is a generator's document stored inside a host file, such as the
+// "release" key of package.json or [tool.git-cliff] in pyproject.toml. Only
+// the embedded document is ever rewritten; the rest of the host is kept byte
+// for byte.
+type embedding interface {
+	// doc returns the embedded document, or nil when the host doesn't have one.
+	doc() ([]byte, error)
+	// replace returns the host with the embedded document replaced by doc.
+	replace(doc []byte) ([]byte, error)
+}
+
+// newEmbedding returns loc's embedded document in host. A Section is handed
+// to the generator as a document of its own; a Key keeps its place in the
+// host's layout, so the generator sees a trimmed-down host.
+func newEmbedding(host []byte, loc Location) (embedding, error) {
+	switch {
+	case loc.Format == formatJSON && loc.Section != nil:
+		return newJSONEmbedding(host, loc.Section)
+	case loc.Format == formatTOML && loc.Section != nil:
+		return newTOMLEmbedding(host, loc.Section, true)
+	case loc.Format == formatTOML && loc.Key != nil:
+		return newTOMLEmbedding(host, loc.Key, false)
+	}
+	return nil, fmt.Errorf("can't embed a document in a %s file", loc.Format)
+}
+
+// lineIndent returns the leading whitespace of the line holding offset i.
+func lineIndent(data []byte, i int) string {
+	line := data[lineStart(data, i):]
+	return string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))])
+}
+
+// reindent prefixes every line of text but the first with indent.
+func reindent(text []byte, indent string) string {
+	return strings.ReplaceAll(strings.TrimRight(string(text), "\n"), "\n", "\n"+indent)
+}
+
+// jsonEmbedding is an object stored under a key path of a JSON host.
+type jsonEmbedding struct {
+	host []byte
+	keys []string
+	// found is the number of keys present in host. When all are, start and
+	// end delimit the embedded object.
+	found      int
+	start, end int
+	// When a key is missing, parent is the start of the innermost object
+	// present, and lastKey and lastEnd the start of its last member and the
+	// end of that member's value (-1 when it has none).
+	parent           int
+	lastKey, lastEnd int
+}
+
+func newJSONEmbedding(host []byte, keys []string) (*jsonEmbedding, error) {
+	e := &jsonEmbedding{host: host, keys: keys}
+	p := &jsonParser{data: host}
+	p.skip()
+	for ; ; e.found++ {
+		if p.peek() != '{' && e.found == 0 {
+			return nil, p.errorf("expected a JSON object")
+		} else if p.peek() != '{' {
+			return nil, p.errorf("expected %q to be an object", strings.Join(keys[:e.found], "."))
+		}
+		if e.found == len(keys) {
+			e.start = p.pos
+			if _, err := p.value(); err != nil {
+				return nil, err
+			}
+			e.end = p.pos
+			return e, nil
+		}
+		ok, err := e.member(p, keys[e.found])
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return e, nil
+		}
+	}
+}
+
+// member moves p from the start of an object to the value of its key member,
+// reporting whether there is one.
+func (e *jsonEmbedding) member(p *jsonParser, key string) (bool, error) {
+	e.parent, e.lastKey, e.lastEnd = p.pos, -1, -1
+	p.pos++ // {
+	p.skip()
+	for p.peek() != '}' {
+		keyStart := p.pos
+		k, err := p.key()
+		if err != nil {
+			return false, err
+		}
+		p.skip()
+		if p.peek() != ':' {
+			return false, p.errorf("expected ':' after object key")
+		}
+		p.pos++
+		p.skip()
+		if k == key {
+			return true, nil
+		}
+		if _, err := p.value(); err != nil {
+			return false, err
+		}
+		e.lastKey, e.lastEnd = keyStart, p.pos
+		if _, err := p.separator('}'); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+func (e *jsonEmbedding) doc() ([]byte, error) {
+	if e.found < len(e.keys) {
+		return nil, nil
+	}
+	// Parse the object as if it started at the margin, so its source text
+	// lines up with the document replace re-indents.
+	text := strings.ReplaceAll(string(e.host[e.start:e.end]), "\n"+lineIndent(e.host, e.start), "\n")
+	obj, err := parseJSONObject([]byte(text))
+	if err != nil {
+		return nil, err
+	}
+	return marshalJSONIndent(obj, jsonIndent(e.host))
+}
+
+func (e *jsonEmbedding) replace(doc []byte) ([]byte, error) {
+	if e.found == len(e.keys) {
+		text := reindent(doc, lineIndent(e.host, e.start))
+		return slices.Concat(e.host[:e.start], []byte(text), e.host[e.end:]), nil
+	}
+
+	// Wrap doc in the missing keys and add it as the parent's last member.
+	value, err := parseJSONLayout(doc)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(e.keys) - 1; i > e.found; i-- {
+		wrapper := &orderedMap{}
+		wrapper.Set(e.keys[i], value)
+		value = wrapper
+	}
+	unit := jsonIndent(e.host)
+	text, err := marshalJSONIndent(value, unit)
+	if err != nil {
+		return nil, err
+	}
+	key, err := encodeJSONValue(e.keys[e.found])
+	if err != nil {
+		return nil, err
+	}
+
+	if e.lastEnd < 0 {
+		outer := lineIndent(e.host, e.parent)
+		member := "\n" + outer + unit + string(key) + ": " + reindent(text, outer+unit) + "\n" + outer
+		return slices.Concat(e.host[:e.parent+1], []byte(member), e.host[e.parent+1:]), nil
+	}
+	indent := lineIndent(e.host, e.lastKey)
+	member := ",\n" + indent + string(key) + ": " + reindent(text, indent)
+	return slices.Concat(e.host[:e.lastEnd], []byte(member), e.host[e.lastEnd:]), nil
+}
+
+// tomlEmbedding is the tables under a key path of a TOML host. With strip,
+// the key path is removed from their headers, so [tool.git-cliff.git] is
+// handed to the generator as [git].
+type tomlEmbedding struct {
+	host   []byte
+	prefix []string
+	strip  bool
+	// tables are the host's tables under prefix, in file order; empty are
+	// [prefix] tables with no keys, which hold nothing to hand over.
+	tables, empty []*tomlSection
+}
+
+func newTOMLEmbedding(host []byte, prefix []string, strip bool) (*tomlEmbedding, error) {
+	sections, err := parseTOMLSections(host)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTOMLTable(sections, prefix, false); err != nil {
+		return nil, err
+	}
+	e := &tomlEmbedding{host: host, prefix: prefix, strip: strip}
+	for _, s := range sections {
+		if e.embedded(s.key, s) {
+			e.tables = append(e.tables, s)
+		} else if slices.Equal(s.key, prefix) && !s.array {
+			e.empty = append(e.empty, s)
+		}
+	}
+	return e, nil
+}
+
+// embedded reports whether the table s, whose full key is key, belongs to
+// the embedded document. A [prefix] table only does when it has keys.
+func (e *tomlEmbedding) embedded(key []string, s *tomlSection) bool {
+	if len(key) < len(e.prefix) || !slices.Equal(key[:len(e.prefix)], e.prefix) {
+		return false
+	}
+	return len(key) > len(e.prefix) || len(s.keys) > 0 && !s.array
+}
+
+// relative returns a table's key as the generator sees it.
+func (e *tomlEmbedding) relative(key []string) []string {
+	if e.strip {
+		return key[len(e.prefix):]
+	}
+	return key
+}
+
+// tableBody returns a table's key/value lines, along with the comments between
+// them.
+func tableBody(data []byte, s *tomlSection) []byte {
+	body := data[s.body:s.end]
+	if len(body) > 0 && !bytes.HasSuffix(body, []byte("\n")) {
+		body = append(slices.Clip(body), '\n')
+	}
+	return body
+}
+
+func (e *tomlEmbedding) doc() ([]byte, error) {
+	if len(e.tables) == 0 {
+		return nil, nil
+	}
+	var sb strings.Builder
+	for _, s := range e.tables {
+		if key := e.relative(s.key); len(key) == 0 {
+			sb.Write(tableBody(e.host, s))
+		}
+	}
+	for _, s := range e.tables {
+		key := e.relative(s.key)
+		if len(key) == 0 {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(tomlHeader(key, s.array) + "\n")
+		sb.Write(tableBody(e.host, s))
+	}
+	return []byte(sb.String()), nil
+}
+
+// replace writes the generated tables back. When they match the host's
+// tables one for one, only their bodies are replaced; otherwise the host's
+// tables are removed and the generated ones written where the first was.
+func (e *tomlEmbedding) replace(doc []byte) ([]byte, error) {
+	sections, err := parseTOMLSections(doc)
+	if err != nil {
+		return nil, err
+	}
+	var tables []*tomlSection
+	for _, s := range sections {
+		key := s.key
+		if e.strip {
+			key = slices.Concat(e.prefix, s.key)
+		}
+		if e.embedded(key, s) {
+			tables = append(tables, s)
+		} else if len(s.keys) > 0 {
+			return nil, fmt.Errorf("generated keys outside [%s]", strings.Join(e.prefix, "."))
+		}
+	}
+
+	type edit struct {
+		start, end int
+		text       []byte
+	}
+	var edits []edit
+	if slices.EqualFunc(e.tables, tables, func(h, g *tomlSection) bool {
+		return h.array == g.array && slices.Equal(e.relative(h.key), g.key)
+	}) {
+		for i, h := range e.tables {
+			edits = append(edits, edit{h.body, h.end, tableBody(doc, tables[i])})
+		}
+	} else {
+		var block []byte
+		for i, s := range tables {
+			if i > 0 {
+				block = append(block, '\n')
+			}
+			key := s.key
+			if e.strip {
+				key = slices.Concat(e.prefix, s.key)
+			}
+			block = append(block, tomlHeader(key, s.array)+"\n"...)
+			block = append(block, tableBody(doc, s)...)
+		}
+		// Empty [prefix] tables go too, as the block may define the table.
+		old := slices.SortedFunc(slices.Values(slices.Concat(e.tables, e.empty)), func(a, b *tomlSection) int {
+			return a.start - b.start
+		})
+		if len(old) == 0 {
+			edits = append(edits, edit{len(e.host), len(e.host), appendSeparator(e.host, block)})
+		}
+		for i, h := range old {
+			if i == 0 {
+				edits = append(edits, edit{h.start, h.end, block})
+				continue
+			}
+			end := h.end
+			for end < len(e.host) && (e.host[end] == '\n' || e.host[end] == '\r') {
+				end++
+			}
+			edits = append(edits, edit{h.start, end, nil})
+		}
+	}
+
+	out := e.host
+	for i := len(edits) - 1; i >= 0; i-- {
+		out = slices.Concat(out[:edits[i].start], edits[i].text, out[edits[i].end:])
+	}
+	return out, nil
+}
+
+// appendSeparator returns text prefixed with the newlines needed to start it
+// after a blank line at the end of data.
+func appendSeparator(data, text []byte) []byte {
+	if len(data) == 0 {
+		return text
+	}
+	var sep []byte
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		sep = append(sep, '\n')
+	}
+	if !bytes.HasSuffix(data, []byte("\n\n")) {
+		sep = append(sep, '\n')
+	}
+	return append(sep, text...)
+}
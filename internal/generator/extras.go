@@ -0,0 +1,220 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File formats understood by applyExtras.
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+	formatTOML = "toml"
+)
+
+// applyExtras deep-merges a generator's "extra" settings from commit-types.json
+// into its generated output. Nested objects are merged key by key; any other
+// value replaces what the generator produced.
+func applyExtras(data []byte, format string, extra map[string]any) ([]byte, error) {
+	if len(extra) == 0 {
+		return data, nil
+	}
+
+	switch format {
+	case formatJSON:
+		doc, err := parseJSONObject(data)
+		if err != nil {
+			return nil, fmt.Errorf("applying extras: %w", err)
+		}
+		mergeJSONObjects(doc, extra)
+		return marshalJSONIndent(doc, jsonIndent(data))
+
+	case formatTOML:
+		// Edit the text so comments and layout survive.
+		out, err := mergeTOMLExtras(data, nil, extra)
+		if err != nil {
+			return nil, fmt.Errorf("applying extras: %w", err)
+		}
+		return out, nil
+
+	case formatYAML:
+		out, err := mergeYAMLExtras(data, extra)
+		if err != nil {
+			return nil, fmt.Errorf("applying extras: %w", err)
+		}
+		return out, nil
+
+	default:
+		return nil, fmt.Errorf("applying extras: unsupported format %q", format)
+	}
+}
+
+// mergeJSONObjects copies src into a parsed JSON object, recursing into
+// nested objects; new keys are added in sorted order.
+func mergeJSONObjects(dst *orderedMap, src map[string]any) {
+	for _, k := range slices.Sorted(maps.Keys(src)) {
+		srcMap, srcIsMap := src[k].(map[string]any)
+		dstMap, dstIsMap := dst.values[k].(*orderedMap)
+		if srcIsMap && dstIsMap {
+			mergeJSONObjects(dstMap, srcMap)
+		} else {
+			dst.Set(k, src[k])
+		}
+	}
+}
+
+// mergeTOMLExtras is mergeJSONObjects for a TOML document, made with the text edits
+// in tomledit.go. Nested objects are merged into their own [table], unless
+// the table holds a plain or inline value there, which they replace.
+func mergeTOMLExtras(data []byte, table []string, extra map[string]any) ([]byte, error) {
+	for _, k := range slices.Sorted(maps.Keys(extra)) {
+		sections, err := parseTOMLSections(data)
+		if err != nil {
+			return nil, err
+		}
+		if m, ok := extra[k].(map[string]any); ok && !hasTOMLValue(sections, table, k) {
+			data, err = mergeTOMLExtras(data, slices.Concat(table, []string{k}), m)
+		} else {
+			var value string
+			if value, err = tomlValue(extra[k]); err != nil {
+				return nil, fmt.Errorf("%s: %w", strings.Join(append(slices.Clone(table), k), "."), err)
+			}
+			data, err = setTOMLValue(data, table, k, value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// hasTOMLValue reports whether the [table] block sets key to a value.
+func hasTOMLValue(sections []*tomlSection, table []string, key string) bool {
+	for _, s := range sections {
+		if s.array || !slices.Equal(s.key, table) {
+			continue
+		}
+		for _, kv := range s.keys {
+			if slices.Equal(kv.key, []string{key}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tomlValue formats a config value as TOML, with objects as inline tables.
+func tomlValue(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return tomlString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, int64, uint64:
+		return fmt.Sprint(v), nil
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "nan", nil
+		case math.IsInf(v, 0):
+			return strings.Replace(fmt.Sprint(v), "Inf", "inf", 1), nil
+		case v == math.Trunc(v) && math.Abs(v) < 1<<53:
+			return strconv.FormatInt(int64(v), 10), nil
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			value, err := tomlValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = value
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]any:
+		if len(v) == 0 {
+			return "{}", nil
+		}
+		var fields []string
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			value, err := tomlValue(v[k])
+			if err != nil {
+				return "", err
+			}
+			fields = append(fields, tomlKeyName(k)+" = "+value)
+		}
+		return "{ " + strings.Join(fields, ", ") + " }", nil
+	case nil:
+		return "", fmt.Errorf("TOML has no null value")
+	}
+	return "", fmt.Errorf("unsupported value %v", v)
+}
+
+// mergeYAMLExtras merges extra into a YAML document whose root is a mapping.
+// Each top-level entry it touches is rewritten in place, so the rest of the
+// file keeps its comments and blank lines.
+func mergeYAMLExtras(data []byte, extra map[string]any) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	var extraNode yaml.Node
+	if err := extraNode.Encode(extra); err != nil {
+		return nil, err
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return encodeYAML(&extraNode)
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("extras need a mapping at the top level of the file")
+	}
+	if doc.Style&yaml.FlowStyle != 0 || len(doc.Content) == 0 {
+		mergeYAMLNodes(doc, &extraNode)
+		return encodeYAML(&root)
+	}
+
+	var edits []textEdit
+	for i := 0; i < len(extraNode.Content)-1; i += 2 {
+		key, value := extraNode.Content[i].Value, extraNode.Content[i+1]
+		if existing := findYAMLKey(doc, key); existing != nil {
+			mergeYAMLNodes(existing, value)
+			value = existing
+		}
+		edit, err := setYAMLEntry(data, doc, key, value)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit)
+	}
+	return applyTextEdits(data, edits), nil
+}
+
+// mergeYAMLNodes is mergeJSONObjects for yaml.Node mappings.
+func mergeYAMLNodes(dst, src *yaml.Node) {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		*dst = *src
+		return
+	}
+	for i := 0; i < len(src.Content)-1; i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		found := false
+		for j := 0; j < len(dst.Content)-1; j += 2 {
+			if dst.Content[j].Value == key.Value {
+				mergeYAMLNodes(dst.Content[j+1], value)
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst.Content = append(dst.Content, key, value)
+		}
+	}
+}
@@ -3,6 +3,10 @@ package generator
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -25,12 +29,30 @@ func testConfig() *config.Config {
 	}
 }
 
+func breakingConfig() *config.Config {
+	cfg := testConfig()
+	group := "Breaking Changes"
+	cfg.Breaking = &config.Breaking{ChangelogGroup: &group}
+	return cfg
+}
+
+func aliasConfig() *config.Config {
+	cfg := testConfig()
+	feat := cfg.Types["feat"]
+	feat.Aliases = []config.Alias{{Name: "feature", Allow: true}}
+	cfg.Types["feat"] = feat
+	fix := cfg.Types["fix"]
+	fix.Aliases = []config.Alias{{Name: "bugfix"}}
+	cfg.Types["fix"] = fix
+	return cfg
+}
+
 // --- Registry tests ---
 
 func TestRegistryAll(t *testing.T) {
 	gens := All()
-	if len(gens) != 7 {
-		t.Errorf("expected 7 registered generators, got %d", len(gens))
+	if len(gens) != 16 {
+		t.Errorf("expected 16 registered generators, got %d", len(gens))
 	}
 }
 
@@ -81,6 +103,128 @@ func TestCliffFresh(t *testing.T) {
 	}
 }
 
+func TestCliffHiddenScopes(t *testing.T) {
+	cfg := testConfig()
+	cfg.Scopes = map[string]config.Scope{
+		"internal": {Description: "Internal tooling", Hidden: true},
+		"parser":   {Description: "The parser"},
+	}
+
+	g := &CliffGenerator{}
+	out, err := g.Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := string(out)
+	if !strings.Contains(s, `message = '^[a-z]+\(internal\)', skip = true`) {
+		t.Error("missing skip rule for hidden scope")
+	}
+	if strings.Contains(s, `\(parser\)`) {
+		t.Error("visible scope should not be skipped")
+	}
+}
+
+func TestCliffBreaking(t *testing.T) {
+	g := &CliffGenerator{}
+	out, err := g.Generate(breakingConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+	git := doc["git"].(map[string]any)
+	if git["protect_breaking_commits"] != true {
+		t.Error("protect_breaking_commits should be enabled")
+	}
+	parsers := git["commit_parsers"].([]any)
+	first := parsers[0].(map[string]any)
+	if first["message"] != `^[a-z]+(\(.*\))?!:` || first["group"] != "Breaking Changes" {
+		t.Errorf("first parser should match '!' breaking commits, got %v", first)
+	}
+	second := parsers[1].(map[string]any)
+	if second["footer"] != "^BREAKING[ -]CHANGE" || second["group"] != "Breaking Changes" {
+		t.Errorf("second parser should match BREAKING CHANGE footers, got %v", second)
+	}
+
+	// Without a breaking section nothing changes
+	out, err = g.Generate(testConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(out), "protect_breaking_commits") || strings.Contains(string(out), "footer") {
+		t.Error("breaking config should not be emitted without a breaking section")
+	}
+}
+
+func TestCliffBreakingTypes(t *testing.T) {
+	cfg := breakingConfig()
+	cfg.Breaking.Types = []string{"fix", "feat"}
+
+	g := &CliffGenerator{}
+	out, err := g.Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), `message = '^(feat|fix)(\(.*\))?!:'`) {
+		t.Errorf("breaking parser should only match allowed types:\n%s", out)
+	}
+
+	cfg.Breaking.Types = []string{}
+	out, err = g.Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(out), "!:") {
+		t.Error("no '!' parser should be emitted when no type may use '!'")
+	}
+	if !strings.Contains(string(out), "footer = ") {
+		t.Error("footer parser should still be emitted")
+	}
+}
+
+func TestCliffBreakingMerge(t *testing.T) {
+	existing := []byte(`[git]
+conventional_commits = true
+commit_parsers = []
+`)
+	g := &CliffGenerator{}
+	out, err := g.Generate(breakingConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+	git := doc["git"].(map[string]any)
+	if git["protect_breaking_commits"] != true {
+		t.Error("protect_breaking_commits should be enabled")
+	}
+	parsers := git["commit_parsers"].([]any)
+	if parsers[1].(map[string]any)["footer"] != "^BREAKING[ -]CHANGE" {
+		t.Errorf("footer parser missing from merged output: %v", parsers)
+	}
+}
+
+func TestCliffAliases(t *testing.T) {
+	g := &CliffGenerator{}
+	out, err := g.Generate(aliasConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := string(out)
+	if !strings.Contains(s, `{ message = '^feature', group = 'Features' }`) {
+		t.Error("missing parser for feature alias")
+	}
+	if !strings.Contains(s, `{ message = '^bugfix', group = 'Bug Fixes' }`) {
+		t.Error("missing parser for bugfix alias")
+	}
+}
+
 func TestCliffMerge(t *testing.T) {
 	existing := []byte(`[changelog]
 header = "custom header"
@@ -151,6 +295,143 @@ func TestCliffIdempotent(t *testing.T) {
 	if !bytes.Equal(second, third) {
 		t.Error("cliff generator is not idempotent after merge")
 	}
+	if !bytes.Equal(first, second) {
+		t.Errorf("merging into fresh output should not change it:\n%s\n---\n%s", first, second)
+	}
+}
+
+func TestCliffMergePreservesLayout(t *testing.T) {
+	existing := []byte(`# Auto-generated header - keep me
+
+[changelog]
+body = """
+{% for group, commits in commits | group_by(attribute="group") %}
+### {{ group }}
+{% endfor %}
+"""
+
+[git]
+# parsers are generated
+commit_parsers = [
+    { message = "old", group = "Old" }, # stale ]
+] # end of parsers
+tag_pattern = "v[0-9].*"
+
+[remote.github]
+owner = "me"
+`)
+	out, err := (&CliffGenerator{}).Generate(breakingConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `# Auto-generated header - keep me
+
+[changelog]
+body = """
+{% for group, commits in commits | group_by(attribute="group") %}
+### {{ group }}
+{% endfor %}
+"""
+
+[git]
+# parsers are generated
+commit_parsers = [
+    { message = '^[a-z]+(\(.*\))?!:', group = 'Breaking Changes' },
+    { footer = '^BREAKING[ -]CHANGE', group = 'Breaking Changes' },
+    { message = '^[a-z]+\(deps\)', skip = true },
+    { message = '^feat', group = 'Features' },
+    { message = '^fix', group = 'Bug Fixes' },
+    { message = '.*', group = '_ignored' },
+] # end of parsers
+tag_pattern = "v[0-9].*"
+protect_breaking_commits = true
+
+[remote.github]
+owner = "me"
+`
+	if string(out) != expected {
+		t.Errorf("unexpected merge output:\n%s", out)
+	}
+}
+
+func TestCliffMergeArrayTables(t *testing.T) {
+	// the layout earlier versions wrote when merging
+	existing := []byte(`[git]
+  conventional_commits = true
+
+  [[git.commit_parsers]]
+    group = 'Old'
+    message = 'old'
+
+  [[git.commit_parsers]]
+    message = '.*'
+    skip = true
+
+[remote]
+  owner = 'me'
+`)
+	out, err := (&CliffGenerator{}).Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(out), "[[git.commit_parsers]]") || strings.Contains(string(out), "old") {
+		t.Errorf("array tables should be replaced by the inline array:\n%s", out)
+	}
+	var doc struct {
+		Git struct {
+			Parsers []commitParser `toml:"commit_parsers"`
+		} `toml:"git"`
+		Remote map[string]any `toml:"remote"`
+	}
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v\n%s", err, out)
+	}
+	if len(doc.Git.Parsers) != 4 || doc.Remote["owner"] != "me" {
+		t.Errorf("unexpected merge result: %+v", doc)
+	}
+}
+
+func TestCliffMergeDottedKeys(t *testing.T) {
+	for _, existing := range []string{
+		"git.conventional_commits = true\ngit.commit_parsers = []\n",
+		"git = { conventional_commits = true }\n",
+	} {
+		_, err := (&CliffGenerator{}).Generate(testConfig(), []byte(existing))
+		if err == nil || !strings.Contains(err.Error(), "must be written as [git] tables") {
+			t.Errorf("%q: expected an error, got %v", existing, err)
+		}
+	}
+}
+
+func TestCliffMergeInvalid(t *testing.T) {
+	for _, existing := range []string{
+		"[git]\nx = 0\"\\",
+		"[git]\nx = \"abc\\",
+		"[git\n",
+	} {
+		if _, err := (&CliffGenerator{}).Generate(testConfig(), []byte(existing)); err == nil {
+			t.Errorf("%q: expected a parse error", existing)
+		}
+	}
+}
+
+func TestTOMLString(t *testing.T) {
+	tests := map[string]string{
+		`^feat`:        `'^feat'`,
+		`^[a-z]+\(x\)`: `'^[a-z]+\(x\)'`,
+		`It's "odd"`:   `"It's \"odd\""`,
+		"tab\there's":  `"tab\there's"`,
+	}
+	for in, want := range tests {
+		got := tomlString(in)
+		if got != want {
+			t.Errorf("tomlString(%q) = %s, want %s", in, got, want)
+		}
+		var doc struct{ V string }
+		if err := toml.Unmarshal([]byte("V = "+got), &doc); err != nil || doc.V != in {
+			t.Errorf("tomlString(%q) does not round-trip: %v %q", in, err, doc.V)
+		}
+	}
 }
 
 func TestCommitlintFresh(t *testing.T) {
@@ -218,6 +499,120 @@ func TestCommitlintMerge(t *testing.T) {
 	}
 }
 
+func TestCommitlintScopeEnum(t *testing.T) {
+	g := &CommitlintGenerator{}
+
+	out, err := g.Generate(testConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(out), "scope-enum") {
+		t.Error("scope-enum should not be emitted without scopes")
+	}
+
+	cfg := testConfig()
+	cfg.Scopes = map[string]config.Scope{
+		"parser": {Description: "The parser"},
+		"cli":    {Description: "Command line"},
+	}
+	out, err = g.Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	rules := doc["rules"].(map[string]any)
+	scopeEnum, ok := rules["scope-enum"].([]any)
+	if !ok {
+		t.Fatal("missing scope-enum rule")
+	}
+	scopes := scopeEnum[2].([]any)
+	// Declared scopes plus excluded_scopes, sorted
+	expected := []string{"cli", "deps", "parser"}
+	if len(scopes) != len(expected) {
+		t.Fatalf("expected scopes %v, got %v", expected, scopes)
+	}
+	for i, s := range scopes {
+		if s != expected[i] {
+			t.Errorf("position %d: expected %q, got %v", i, expected[i], s)
+		}
+	}
+
+	// Dropping the scopes drops the rule from the existing file.
+	out, err = g.Generate(testConfig(), out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(out), "scope-enum") {
+		t.Errorf("scope-enum should be removed once scopes are gone:\n%s", out)
+	}
+}
+
+func TestCommitlintBreakingForbidden(t *testing.T) {
+	g := &CommitlintGenerator{}
+	cfg := breakingConfig()
+
+	out, err := g.Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(out), "subject-exclamation-mark") {
+		t.Error("'!' should be allowed by default")
+	}
+
+	cfg.Breaking.Types = []string{}
+	out, err = g.Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	rule, ok := doc["rules"].(map[string]any)["subject-exclamation-mark"].([]any)
+	if !ok || rule[0] != float64(2) || rule[1] != "never" {
+		t.Errorf("expected subject-exclamation-mark [2, never], got %v", rule)
+	}
+
+	// Allowing "!" again drops the rule from the existing file.
+	cfg.Breaking.Types = nil
+	out, err = g.Generate(cfg, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(out), "subject-exclamation-mark") {
+		t.Errorf("subject-exclamation-mark should be removed once '!' is allowed:\n%s", out)
+	}
+}
+
+func TestCommitlintAliases(t *testing.T) {
+	g := &CommitlintGenerator{}
+	out, err := g.Generate(aliasConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	typeEnum := doc["rules"].(map[string]any)["type-enum"].([]any)
+	types := typeEnum[2].([]any)
+	// Allowed aliases follow their type; others are not accepted for new commits
+	expected := []string{"feat", "feature", "fix", "chore"}
+	if len(types) != len(expected) {
+		t.Fatalf("expected type-enum %v, got %v", expected, types)
+	}
+	for i, name := range types {
+		if name != expected[i] {
+			t.Errorf("position %d: expected %q, got %v", i, expected[i], name)
+		}
+	}
+}
+
 func TestCommitlintIdempotent(t *testing.T) {
 	g := &CommitlintGenerator{}
 	cfg := testConfig()
@@ -413,6 +808,27 @@ func TestChangieFresh(t *testing.T) {
 	}
 }
 
+func TestChangieBreaking(t *testing.T) {
+	g := &ChangieGenerator{}
+	out, err := g.Generate(breakingConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+	kinds := doc["kinds"].([]any)
+	if len(kinds) != 3 {
+		t.Fatalf("expected 3 kinds, got %d", len(kinds))
+	}
+	first := kinds[0].(map[string]any)
+	if first["label"] != "Breaking Changes" || first["auto"] != "major" {
+		t.Errorf("expected breaking kind first with auto major, got %v", first)
+	}
+}
+
 func TestChangieMerge(t *testing.T) {
 	existing := []byte(`changesDir: .changes
 headerPath: header.tpl.md
@@ -492,6 +908,66 @@ func TestSemanticReleaseFresh(t *testing.T) {
 	}
 }
 
+func TestSemanticReleaseBreaking(t *testing.T) {
+	cfg := breakingConfig()
+	cfg.Breaking.Bump = "minor"
+
+	g := &SemanticReleaseGenerator{}
+	out, err := g.Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	analyzer := doc["plugins"].([]any)[0].([]any)
+	rules := analyzer[1].(map[string]any)["releaseRules"].([]any)
+	first := rules[0].(map[string]any)
+	if first["breaking"] != true || first["release"] != "minor" {
+		t.Errorf("expected breaking release rule first, got %v", first)
+	}
+	if _, ok := first["type"]; ok {
+		t.Error("breaking rule should not be tied to a type")
+	}
+}
+
+func TestSemanticReleaseAliases(t *testing.T) {
+	g := &SemanticReleaseGenerator{}
+	out, err := g.Generate(aliasConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	analyzer := doc["plugins"].([]any)[0].([]any)[1].(map[string]any)
+
+	releases := map[string]any{}
+	for _, r := range analyzer["releaseRules"].([]any) {
+		rm := r.(map[string]any)
+		releases[rm["type"].(string)] = rm["release"]
+	}
+	if releases["feature"] != "minor" {
+		t.Errorf("feature alias should release minor, got %v", releases["feature"])
+	}
+	if releases["bugfix"] != "patch" {
+		t.Errorf("bugfix alias should release patch, got %v", releases["bugfix"])
+	}
+
+	sections := map[string]any{}
+	for _, p := range analyzer["presetConfig"].(map[string]any)["types"].([]any) {
+		pm := p.(map[string]any)
+		sections[pm["type"].(string)] = pm["section"]
+	}
+	if sections["bugfix"] != "Bug Fixes" {
+		t.Errorf("bugfix alias should share the Bug Fixes section, got %v", sections["bugfix"])
+	}
+}
+
 func TestSemanticReleaseMerge(t *testing.T) {
 	existing := []byte(`{
   "branches": ["main", "next"],
@@ -587,17 +1063,9 @@ func TestReleasePlzFresh(t *testing.T) {
 	}
 }
 
-func TestReleasePlzMerge(t *testing.T) {
-	existing := []byte(`[workspace]
-allow_dirty = true
-
-[changelog]
-commit_parsers = [
-    { message = "old", group = "Old" },
-]
-`)
+func TestReleasePlzBreaking(t *testing.T) {
 	g := &ReleasePlzGenerator{}
-	out, err := g.Generate(testConfig(), existing)
+	out, err := g.Generate(breakingConfig(), nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -606,11 +1074,63 @@ commit_parsers = [
 	if err := toml.Unmarshal(out, &doc); err != nil {
 		t.Fatalf("output not valid TOML: %v", err)
 	}
-
-	// workspace preserved
-	ws := doc["workspace"].(map[string]any)
-	if ws["allow_dirty"] != true {
-		t.Error("workspace.allow_dirty not preserved")
+	changelog := doc["changelog"].(map[string]any)
+	if changelog["protect_breaking_commits"] != true {
+		t.Error("protect_breaking_commits should be enabled")
+	}
+	parsers := changelog["commit_parsers"].([]any)
+	if parsers[0].(map[string]any)["group"] != "Breaking Changes" {
+		t.Errorf("expected breaking parser first, got %v", parsers[0])
+	}
+}
+
+func TestReleasePlzAliases(t *testing.T) {
+	g := &ReleasePlzGenerator{}
+	out, err := g.Generate(aliasConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+	groups := map[string]any{}
+	for _, p := range doc["changelog"].(map[string]any)["commit_parsers"].([]any) {
+		pm := p.(map[string]any)
+		if msg, ok := pm["message"].(string); ok {
+			groups[msg] = pm["group"]
+		}
+	}
+	if groups["^feature"] != "Features" || groups["^bugfix"] != "Bug Fixes" {
+		t.Errorf("alias parsers missing or in the wrong group: %v", groups)
+	}
+}
+
+func TestReleasePlzMerge(t *testing.T) {
+	existing := []byte(`[workspace]
+allow_dirty = true
+
+[changelog]
+commit_parsers = [
+    { message = "old", group = "Old" },
+]
+`)
+	g := &ReleasePlzGenerator{}
+	out, err := g.Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+
+	// workspace preserved
+	ws := doc["workspace"].(map[string]any)
+	if ws["allow_dirty"] != true {
+		t.Error("workspace.allow_dirty not preserved")
 	}
 
 	changelog := doc["changelog"].(map[string]any)
@@ -646,4 +1166,2215 @@ func TestReleasePlzIdempotent(t *testing.T) {
 	if !bytes.Equal(second, third) {
 		t.Error("release-plz generator is not idempotent")
 	}
+	if !bytes.Equal(first, second) {
+		t.Errorf("merging into fresh output should not change it:\n%s\n---\n%s", first, second)
+	}
+}
+
+func TestReleasePlzMergePreservesComments(t *testing.T) {
+	existing := []byte(`# release-plz settings
+[workspace]
+changelog_update = true # keep
+
+[changelog]
+body = """
+{{ version }}
+"""
+commit_parsers = []
+
+[[package]]
+name = "api"
+publish = false # internal
+`)
+	cfg := testConfig()
+	cfg.Packages = map[string]config.Package{"crates/api": {}, "crates/cli": {}}
+	out, err := (&ReleasePlzGenerator{}).Generate(cfg, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := string(out)
+	for _, want := range []string{
+		"# release-plz settings\n",
+		"changelog_update = true # keep\n",
+		"body = \"\"\"\n{{ version }}\n\"\"\"\ncommit_parsers = [\n    { message = '^[a-z]+\\(deps\\)', skip = true },\n",
+		"name = \"api\"\npublish = false # internal\nchangelog_path = 'crates/api/CHANGELOG.md'\n",
+		"\n\n[[package]]\nname = 'cli'\nchangelog_path = 'crates/cli/CHANGELOG.md'\n",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected %q in output:\n%s", want, s)
+		}
+	}
+}
+
+// --- Per-generator override tests ---
+
+func overrideConfig() *config.Config {
+	cfg := testConfig()
+	added := "Added"
+	hidden := true
+	cfg.Generators = map[string]config.GeneratorConfig{
+		"changie": {
+			Types: map[string]config.TypeOverride{"feat": {ChangelogGroup: &added}, "fix": {Hidden: &hidden}},
+			Extra: map[string]any{"changesFormat": "* {{.Body}}"},
+		},
+		"release-please": {
+			Extra: map[string]any{"bump-minor-pre-major": true, "packages": map[string]any{".": map[string]any{"component": "app"}}},
+		},
+		"cliff": {
+			Extra: map[string]any{"git": map[string]any{"filter_unconventional": false}},
+		},
+	}
+	return cfg
+}
+
+func TestGeneratorTypeOverrides(t *testing.T) {
+	cfg := overrideConfig()
+
+	out, err := (&ChangieGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc struct {
+		Kinds []struct {
+			Label string `yaml:"label"`
+		} `yaml:"kinds"`
+	}
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+	if len(doc.Kinds) != 1 || doc.Kinds[0].Label != "Added" {
+		t.Errorf("expected only an Added kind, got %+v", doc.Kinds)
+	}
+
+	// Other generators keep the base groups.
+	out, err = (&CliffGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), "Features") || !strings.Contains(string(out), "Bug Fixes") {
+		t.Errorf("cliff should use the base groups:\n%s", out)
+	}
+}
+
+func TestGeneratorExtras(t *testing.T) {
+	cfg := overrideConfig()
+
+	out, err := (&ReleasePleaseGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	if doc["bump-minor-pre-major"] != true {
+		t.Error("top-level extra not applied")
+	}
+	root := doc["packages"].(map[string]any)["."].(map[string]any)
+	if root["component"] != "app" {
+		t.Error("nested extra not applied")
+	}
+	if _, ok := root["changelog-sections"]; !ok {
+		t.Error("nested extra should merge, not replace")
+	}
+
+	out, err = (&CliffGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var cliff map[string]any
+	if err := toml.Unmarshal(out, &cliff); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+	git := cliff["git"].(map[string]any)
+	if git["filter_unconventional"] != false {
+		t.Error("cliff extra not applied")
+	}
+	if _, ok := git["commit_parsers"]; !ok {
+		t.Error("cliff extra should merge into git, not replace it")
+	}
+
+	out, err = (&ChangieGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), "changesFormat: '* {{.Body}}'") {
+		t.Errorf("changie extra not applied:\n%s", out)
+	}
+}
+
+func TestCliffExtrasKeepLayout(t *testing.T) {
+	cfg := testConfig()
+	cfg.Generators = map[string]config.GeneratorConfig{
+		"cliff": {Extra: map[string]any{
+			"changelog": map[string]any{
+				"trim":           true,
+				"postprocessors": []any{map[string]any{"pattern": "<REPO>", "replace": "https://example.com"}},
+			},
+			"git":    map[string]any{"filter_unconventional": false},
+			"remote": map[string]any{"github": map[string]any{"owner": "me"}},
+		}},
+	}
+	out, err := (&CliffGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"# git-cliff config\n",
+		"body = \"\"\"\n",
+		"trim = true\n",
+		"postprocessors = [{ pattern = '<REPO>', replace = 'https://example.com' }]\n",
+		"filter_unconventional = false\n",
+		"commit_parsers = [\n",
+		"\n\n[remote.github]\nowner = 'me'\n",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output should contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(string(out), "[[") || strings.Contains(string(out), "\n  [") {
+		t.Errorf("extras should not re-encode the file:\n%s", out)
+	}
+	var doc map[string]any
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+}
+
+func TestYAMLExtrasKeepLayout(t *testing.T) {
+	cfg := testConfig()
+	cfg.Generators = map[string]config.GeneratorConfig{
+		"goreleaser": {Extra: map[string]any{
+			"changelog": map[string]any{"sort": "asc"},
+			"release":   map[string]any{"draft": true},
+		}},
+	}
+	existing := []byte("version: 2\n\n# build settings\nbuilds:\n  - binary: app\n\nchangelog:\n  use: git\n")
+	out, err := (&GoReleaserGenerator{}).Generate(cfg, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(out), "version: 2\n\n# build settings\nbuilds:\n  - binary: app\n\nchangelog:\n  use: git\n  groups:\n    - title: Features\n") {
+		t.Errorf("extras should keep the layout and two-space indent:\n%s", out)
+	}
+	if !strings.HasSuffix(string(out), "\n  sort: asc\nrelease:\n  draft: true\n") {
+		t.Errorf("extras should be merged into changelog and added at the end:\n%s", out)
+	}
+}
+
+func TestYAMLExtrasNeedMapping(t *testing.T) {
+	cfg := testConfig()
+	cfg.Generators = map[string]config.GeneratorConfig{
+		"github-labels": {Extra: map[string]any{"name": "oops"}},
+	}
+	_, err := (&GitHubLabelsGenerator{}).Generate(cfg, nil)
+	if err == nil || !strings.Contains(err.Error(), "mapping at the top level") {
+		t.Fatalf("expected an error for extras on a list of labels, got %v", err)
+	}
+}
+
+func TestGeneratorExtrasIdempotent(t *testing.T) {
+	cfg := overrideConfig()
+	for _, g := range []Generator{&ReleasePleaseGenerator{}, &CliffGenerator{}, &ChangieGenerator{}} {
+		first, err := g.Generate(cfg, nil)
+		if err != nil {
+			t.Fatalf("%s first: %v", g.Name(), err)
+		}
+		second, err := g.Generate(cfg, first)
+		if err != nil {
+			t.Fatalf("%s second: %v", g.Name(), err)
+		}
+		if !bytes.Equal(first, second) {
+			t.Errorf("%s is not idempotent with extras:\nfirst:\n%s\nsecond:\n%s", g.Name(), first, second)
+		}
+	}
+}
+
+// --- Monorepo package tests ---
+
+func packageConfig() *config.Config {
+	cfg := testConfig()
+	hidden := true
+	cfg.Packages = map[string]config.Package{
+		"crates/core": {},
+		"crates/cli":  {Name: "mytool", Types: map[string]config.TypeOverride{"fix": {Hidden: &hidden}}},
+	}
+	return cfg
+}
+
+func TestReleasePleasePackages(t *testing.T) {
+	existing := []byte(`{
+  "packages": {
+    "crates/core": {"component": "core"},
+    "legacy": {"changelog-sections": []}
+  }
+}
+`)
+	out, err := (&ReleasePleaseGenerator{}).Generate(packageConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc struct {
+		Packages map[string]struct {
+			Component string             `json:"component"`
+			Sections  []changelogSection `json:"changelog-sections"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	if _, ok := doc.Packages["."]; ok {
+		t.Error("root package should not be added when packages are configured")
+	}
+	if _, ok := doc.Packages["legacy"]; !ok {
+		t.Error("unlisted packages should be preserved")
+	}
+	core := doc.Packages["crates/core"]
+	if core.Component != "core" {
+		t.Error("component not preserved")
+	}
+	if len(core.Sections) != 3 || core.Sections[1].Section != "Bug Fixes" {
+		t.Errorf("unexpected core sections: %+v", core.Sections)
+	}
+	cli := doc.Packages["crates/cli"]
+	if len(cli.Sections) != 3 || !cli.Sections[1].Hidden {
+		t.Errorf("fix should be hidden for crates/cli: %+v", cli.Sections)
+	}
+}
+
+func TestReleasePleaseRootPackage(t *testing.T) {
+	existing := []byte(`{
+  "packages": {
+    ".": {"component": "root", "changelog-sections": [{"type": "old", "section": "Old"}]}
+  }
+}
+`)
+	out, err := (&ReleasePleaseGenerator{}).Generate(packageConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc struct {
+		Packages map[string]struct {
+			Component string             `json:"component"`
+			Sections  []changelogSection `json:"changelog-sections"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	root := doc.Packages["."]
+	if root.Component != "root" {
+		t.Error("component not preserved")
+	}
+	if !slices.Equal(root.Sections, buildChangelogSections(packageConfig())) {
+		t.Errorf("root package should get the top-level sections: %+v", root.Sections)
+	}
+	if _, ok := doc.Packages["crates/cli"]; !ok {
+		t.Error("configured packages should still be added")
+	}
+}
+
+func TestReleasePlzPackages(t *testing.T) {
+	g := &ReleasePlzGenerator{}
+	cfg := packageConfig()
+
+	fresh, err := g.Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc struct {
+		Package []map[string]any `toml:"package"`
+	}
+	if err := toml.Unmarshal(fresh, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+	if len(doc.Package) != 2 || doc.Package[0]["name"] != "mytool" || doc.Package[0]["changelog_path"] != "crates/cli/CHANGELOG.md" {
+		t.Errorf("unexpected packages: %v", doc.Package)
+	}
+
+	existing := []byte(`[[package]]
+name = "core"
+publish = false
+
+[[package]]
+name = "other"
+`)
+	merged, err := g.Generate(cfg, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	doc.Package = nil
+	if err := toml.Unmarshal(merged, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+	if len(doc.Package) != 3 {
+		t.Fatalf("expected 3 packages, got %v", doc.Package)
+	}
+	core := doc.Package[0]
+	if core["name"] != "core" || core["publish"] != false || core["changelog_path"] != "crates/core/CHANGELOG.md" {
+		t.Errorf("core entry should be updated in place: %v", core)
+	}
+	if doc.Package[1]["name"] != "other" || doc.Package[2]["name"] != "mytool" {
+		t.Errorf("unexpected package order: %v", doc.Package)
+	}
+}
+
+func TestChangieProjects(t *testing.T) {
+	g := &ChangieGenerator{}
+	existing := []byte("# changie config\nchangesDir: .changes\nkinds: []\n")
+	out, err := g.Generate(packageConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), "# changie config") {
+		t.Error("comment not preserved")
+	}
+
+	var doc struct {
+		Projects []changieProject `yaml:"projects"`
+	}
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+	expected := []changieProject{
+		{Label: "mytool", Key: "mytool", ChangelogPath: "crates/cli/CHANGELOG.md"},
+		{Label: "core", Key: "core", ChangelogPath: "crates/core/CHANGELOG.md"},
+	}
+	if len(doc.Projects) != 2 || doc.Projects[0] != expected[0] || doc.Projects[1] != expected[1] {
+		t.Errorf("expected projects %+v, got %+v", expected, doc.Projects)
+	}
+
+	again, err := g.Generate(packageConfig(), out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Error("changie generator is not idempotent with projects")
+	}
+}
+
+// --- Commitizen tests ---
+
+type commitizenDoc struct {
+	Tool struct {
+		Commitizen struct {
+			Name      string `toml:"name"`
+			Version   string `toml:"version"`
+			Customize struct {
+				SchemaPattern   string            `toml:"schema_pattern"`
+				BumpMap         map[string]string `toml:"bump_map"`
+				ChangeTypeMap   map[string]string `toml:"change_type_map"`
+				ChangeTypeOrder []string          `toml:"change_type_order"`
+				Questions       []struct {
+					Name    string `toml:"name"`
+					Type    string `toml:"type"`
+					Choices []struct {
+						Value string `toml:"value"`
+						Name  string `toml:"name"`
+					} `toml:"choices"`
+				} `toml:"questions"`
+			} `toml:"customize"`
+		} `toml:"commitizen"`
+	} `toml:"tool"`
+	Project map[string]any `toml:"project"`
+}
+
+func TestCommitizenFresh(t *testing.T) {
+	out, err := (&CommitizenGenerator{}).Generate(breakingConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc commitizenDoc
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+	cz := doc.Tool.Commitizen
+	if cz.Name != "cz_customize" {
+		t.Errorf("expected cz_customize, got %q", cz.Name)
+	}
+	c := cz.Customize
+	if c.SchemaPattern != `^(feat|fix|chore)(\(\S+\))?!?:\s.+` {
+		t.Errorf("unexpected schema_pattern: %s", c.SchemaPattern)
+	}
+	if c.BumpMap[`^feat\b`] != "MINOR" || c.BumpMap[`^fix\b`] != "PATCH" || c.BumpMap[`^.+!$`] != "MAJOR" {
+		t.Errorf("unexpected bump_map: %v", c.BumpMap)
+	}
+	if _, ok := c.BumpMap[`^chore\b`]; ok {
+		t.Error("chore has no bump and should not be in bump_map")
+	}
+	if c.ChangeTypeMap["feat"] != "Features" || c.ChangeTypeMap["BREAKING CHANGE"] != "Breaking Changes" {
+		t.Errorf("unexpected change_type_map: %v", c.ChangeTypeMap)
+	}
+	if _, ok := c.ChangeTypeMap["chore"]; ok {
+		t.Error("hidden chore should not be in change_type_map")
+	}
+	if strings.Join(c.ChangeTypeOrder, ",") != "Breaking Changes,Features,Bug Fixes" {
+		t.Errorf("unexpected change_type_order: %v", c.ChangeTypeOrder)
+	}
+
+	if len(c.Questions) != 3 || c.Questions[0].Name != "change_type" {
+		t.Fatalf("unexpected questions: %+v", c.Questions)
+	}
+	choices := c.Questions[0].Choices
+	if len(choices) != 3 || choices[0].Value != "feat" || choices[0].Name != "feat: A new feature" {
+		t.Errorf("unexpected choices: %+v", choices)
+	}
+	if c.Questions[1].Type != "input" {
+		t.Errorf("scope should be free-form without configured scopes, got %q", c.Questions[1].Type)
+	}
+}
+
+func TestCommitizenScopesAndAliases(t *testing.T) {
+	cfg := aliasConfig()
+	cfg.Scopes = map[string]config.Scope{"api": {Description: "The API"}}
+
+	out, err := (&CommitizenGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc commitizenDoc
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+	c := doc.Tool.Commitizen.Customize
+	if c.SchemaPattern != `^(feat|feature|fix|chore)(\(\S+\))?!?:\s.+` {
+		t.Errorf("only allowed aliases should pass schema_pattern: %s", c.SchemaPattern)
+	}
+	if c.ChangeTypeMap["bugfix"] != "Bug Fixes" {
+		t.Errorf("aliases should map to their type's group: %v", c.ChangeTypeMap)
+	}
+	scope := c.Questions[1]
+	if scope.Type != "list" || len(scope.Choices) != 3 || scope.Choices[1].Name != "api: The API" {
+		t.Errorf("unexpected scope question: %+v", scope)
+	}
+}
+
+func TestCommitizenMerge(t *testing.T) {
+	existing := []byte(`[project]
+name = "service"
+version = "1.2.3"
+
+[tool.commitizen]
+version = "1.2.3"
+name = "cz_conventional_commits"
+
+[tool.ruff]
+line-length = 100
+`)
+	out, err := (&CommitizenGenerator{}).Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc commitizenDoc
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+	if doc.Project["name"] != "service" {
+		t.Error("[project] not preserved")
+	}
+	if doc.Tool.Commitizen.Version != "1.2.3" {
+		t.Error("commitizen version not preserved")
+	}
+	if doc.Tool.Commitizen.Name != "cz_customize" {
+		t.Errorf("expected cz_customize, got %q", doc.Tool.Commitizen.Name)
+	}
+	if !strings.Contains(string(out), "line-length = 100") {
+		t.Error("[tool.ruff] not preserved")
+	}
+}
+
+func TestCommitizenMergeKeepsLayout(t *testing.T) {
+	existing := `# project settings
+[tool.commitizen]
+version = "1.2.3" # bumped by cz
+name = "cz_conventional_commits"
+
+[tool.commitizen.customize]
+example = "old"
+
+[[tool.commitizen.customize.questions]]
+name = "old"
+
+[tool.ruff]
+line-length = 100
+`
+	out, err := (&CommitizenGenerator{}).Generate(testConfig(), []byte(existing))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	prefix := "# project settings\n[tool.commitizen]\nversion = \"1.2.3\" # bumped by cz\nname = 'cz_customize'\n\n[tool.commitizen.customize]\n"
+	if !strings.HasPrefix(string(out), prefix) {
+		t.Errorf("[tool.commitizen] should only have name changed:\n%s", out)
+	}
+	if !strings.HasSuffix(string(out), "type = 'input'\n\n[tool.ruff]\nline-length = 100\n") {
+		t.Errorf("[tool.ruff] should follow the commitizen tables unchanged:\n%s", out)
+	}
+	if strings.Contains(string(out), "old") {
+		t.Errorf("old customize tables should be replaced:\n%s", out)
+	}
+
+	fresh, err := (&CommitizenGenerator{}).Generate(testConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	again, err := (&CommitizenGenerator{}).Generate(testConfig(), fresh)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(fresh, again) {
+		t.Errorf("merging into fresh output should not change it:\n%s\n---\n%s", fresh, again)
+	}
+}
+
+func TestCommitizenIdempotent(t *testing.T) {
+	g := &CommitizenGenerator{}
+	cfg := testConfig()
+
+	first, err := g.Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("first: %v", err)
+	}
+	second, err := g.Generate(cfg, first)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	third, err := g.Generate(cfg, second)
+	if err != nil {
+		t.Fatalf("third: %v", err)
+	}
+	if !bytes.Equal(second, third) {
+		t.Error("commitizen generator is not idempotent")
+	}
+}
+
+func TestCommitlintPrompt(t *testing.T) {
+	cfg := testConfig()
+	feat := cfg.Types["feat"]
+	feat.Emoji = "✨"
+	cfg.Types["feat"] = feat
+	cfg.Scopes = map[string]config.Scope{"api": {Description: "The API"}}
+
+	out, err := (&CommitlintGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Prompt choices keep the configured type order.
+	feat1 := strings.Index(string(out), `"feat": {`)
+	fix1 := strings.Index(string(out), `"fix": {`)
+	chore1 := strings.Index(string(out), `"chore": {`)
+	if feat1 < 0 || feat1 > fix1 || fix1 > chore1 {
+		t.Errorf("type enum not in type order:\n%s", out)
+	}
+
+	var doc struct {
+		Prompt struct {
+			Questions struct {
+				Type struct {
+					Enum map[string]map[string]string `json:"enum"`
+				} `json:"type"`
+			} `json:"questions"`
+			Scopes []map[string]string `json:"scopes"`
+		} `json:"prompt"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	enum := doc.Prompt.Questions.Type.Enum
+	if enum["feat"]["description"] != "A new feature" || enum["feat"]["title"] != "Features" || enum["feat"]["emoji"] != "✨" {
+		t.Errorf("unexpected feat entry: %v", enum["feat"])
+	}
+	if _, ok := enum["chore"]["title"]; ok {
+		t.Error("chore has no changelog group and should have no title")
+	}
+	scopes := doc.Prompt.Scopes
+	if len(scopes) != 2 || scopes[0]["value"] != "api" || scopes[0]["name"] != "api: The API" || scopes[1]["value"] != "deps" {
+		t.Errorf("unexpected scopes: %v", scopes)
+	}
+}
+
+func TestCommitlintPromptScopeOverrides(t *testing.T) {
+	cfg := testConfig()
+	cfg.Scopes = map[string]config.Scope{
+		"api":  {},
+		"docs": {Types: []string{"chore"}},
+	}
+
+	out, err := (&CommitlintGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc struct {
+		Prompt struct {
+			ScopeOverrides map[string][]map[string]string `json:"scopeOverrides"`
+		} `json:"prompt"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	overrides := doc.Prompt.ScopeOverrides
+	if _, ok := overrides["chore"]; ok {
+		t.Error("chore may use every scope and needs no override")
+	}
+	feat := overrides["feat"]
+	if len(overrides) != 2 || len(feat) != 2 || feat[0]["value"] != "api" || feat[1]["value"] != "deps" {
+		t.Errorf("unexpected scope overrides: %v", overrides)
+	}
+
+	cfg.Scopes["docs"] = config.Scope{}
+	out, err = (&CommitlintGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(out), "scopeOverrides") {
+		t.Errorf("no overrides expected when every scope allows every type:\n%s", out)
+	}
+}
+
+func TestCommitlintPromptMerge(t *testing.T) {
+	existing := []byte(`{
+  "prompt": {
+    "useEmoji": true,
+    "questions": {
+      "type": {"description": "Pick one", "enum": {"old": {"description": "Old"}}},
+      "subject": {"description": "Summary"}
+    }
+  }
+}
+`)
+	out, err := (&CommitlintGenerator{}).Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	prompt := doc["prompt"].(map[string]any)
+	if prompt["useEmoji"] != true {
+		t.Error("prompt options not preserved")
+	}
+	questions := prompt["questions"].(map[string]any)
+	if _, ok := questions["subject"]; !ok {
+		t.Error("other questions not preserved")
+	}
+	typeQuestion := questions["type"].(map[string]any)
+	if typeQuestion["description"] != "Pick one" {
+		t.Error("type question description not preserved")
+	}
+	enum := typeQuestion["enum"].(map[string]any)
+	if _, ok := enum["old"]; ok || len(enum) != 3 {
+		t.Errorf("type enum should be replaced, got %v", enum)
+	}
+	if _, ok := prompt["scopes"]; ok {
+		t.Error("scopes should only be written when scopes are configured")
+	}
+}
+
+func TestCommitlintPromptDropsScopes(t *testing.T) {
+	g := &CommitlintGenerator{}
+	cfg := testConfig()
+	cfg.Scopes = map[string]config.Scope{"api": {}, "docs": {Types: []string{"chore"}}}
+	out, err := g.Generate(cfg, []byte(`{"prompt": {"useEmoji": true}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), `"scopes"`) || !strings.Contains(string(out), `"scopeOverrides"`) {
+		t.Fatalf("scopes and overrides should be written:\n%s", out)
+	}
+
+	cfg.Scopes = map[string]config.Scope{"api": {}, "docs": {}}
+	out, err = g.Generate(cfg, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), `"scopes"`) || strings.Contains(string(out), `"scopeOverrides"`) {
+		t.Errorf("overrides should be removed once no scope limits its types:\n%s", out)
+	}
+
+	out, err = g.Generate(testConfig(), out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(out), `"scopes"`) {
+		t.Errorf("scopes should be removed once none are configured:\n%s", out)
+	}
+	if !strings.Contains(string(out), `"useEmoji": true`) {
+		t.Errorf("other prompt options should be kept:\n%s", out)
+	}
+}
+
+// --- Cocogitto tests ---
+
+type cogCommitType struct {
+	ChangelogTitle    string `toml:"changelog_title"`
+	OmitFromChangelog bool   `toml:"omit_from_changelog"`
+	BumpMinor         bool   `toml:"bump_minor"`
+	BumpPatch         bool   `toml:"bump_patch"`
+}
+
+func TestCocogittoFresh(t *testing.T) {
+	out, err := (&CocogittoGenerator{}).Generate(aliasConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc struct {
+		CommitTypes map[string]cogCommitType `toml:"commit_types"`
+	}
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+	types := doc.CommitTypes
+	if len(types) != 5 {
+		t.Errorf("expected 3 types and 2 aliases, got %v", types)
+	}
+	if types["feat"] != (cogCommitType{ChangelogTitle: "Features", BumpMinor: true}) {
+		t.Errorf("unexpected feat: %+v", types["feat"])
+	}
+	if types["fix"] != (cogCommitType{ChangelogTitle: "Bug Fixes", BumpPatch: true}) {
+		t.Errorf("unexpected fix: %+v", types["fix"])
+	}
+	if types["chore"] != (cogCommitType{ChangelogTitle: "Other changes", OmitFromChangelog: true}) {
+		t.Errorf("unexpected chore: %+v", types["chore"])
+	}
+	if types["bugfix"] != types["fix"] {
+		t.Errorf("alias should mirror its type: %+v", types["bugfix"])
+	}
+}
+
+func TestCocogittoMerge(t *testing.T) {
+	existing := []byte(`tag_prefix = "v" # release tags
+ignore_merge_commits = true
+
+[commit_types]
+hotfix = { changelog_title = "Hotfixes" }
+
+[changelog]
+path = "CHANGELOG.md"
+authors = [{ signature = "Jane", username = "jane" }]
+
+[bump_profiles.hotfix]
+pre_bump_hooks = []
+`)
+	out, err := (&CocogittoGenerator{}).Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	prefix := "tag_prefix = \"v\" # release tags\nignore_merge_commits = true\n\n[commit_types]\n"
+	suffix := "\n\n[changelog]\npath = \"CHANGELOG.md\"\nauthors = [{ signature = \"Jane\", username = \"jane\" }]\n\n[bump_profiles.hotfix]\npre_bump_hooks = []\n"
+	if !strings.HasPrefix(string(out), prefix) || !strings.HasSuffix(string(out), suffix) {
+		t.Errorf("only [commit_types] should change:\n%s", out)
+	}
+
+	var doc map[string]any
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid TOML: %v", err)
+	}
+	if doc["tag_prefix"] != "v" || doc["ignore_merge_commits"] != true {
+		t.Error("top-level settings not preserved")
+	}
+	changelog := doc["changelog"].(map[string]any)
+	if changelog["path"] != "CHANGELOG.md" || len(changelog["authors"].([]any)) != 1 {
+		t.Error("[changelog] not preserved")
+	}
+	types := doc["commit_types"].(map[string]any)
+	if _, ok := types["hotfix"]; ok || len(types) != 3 {
+		t.Errorf("commit_types should be replaced, got %v", types)
+	}
+}
+
+func TestCocogittoIdempotent(t *testing.T) {
+	g := &CocogittoGenerator{}
+	cfg := testConfig()
+
+	first, err := g.Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("first: %v", err)
+	}
+	second, err := g.Generate(cfg, first)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Error("cocogitto generator is not idempotent")
+	}
+
+	// [commit_types] is added after the existing settings
+	third, err := g.Generate(cfg, []byte("tag_prefix = \"v\"\n"))
+	if err != nil {
+		t.Fatalf("third: %v", err)
+	}
+	if !bytes.Equal(third, slices.Concat([]byte("tag_prefix = \"v\"\n\n"), first)) {
+		t.Errorf("unexpected output:\n%s", third)
+	}
+}
+
+// --- GoReleaser tests ---
+
+type goreleaserDoc struct {
+	Changelog struct {
+		Sort    string `yaml:"sort"`
+		Filters struct {
+			Exclude []string `yaml:"exclude"`
+			Include []string `yaml:"include"`
+		} `yaml:"filters"`
+		Groups []goreleaserGroup `yaml:"groups"`
+	} `yaml:"changelog"`
+	ProjectName string `yaml:"project_name"`
+}
+
+func TestGoReleaserFresh(t *testing.T) {
+	cfg := breakingConfig()
+	perf := "Bug Fixes"
+	cfg.Types["perf"] = config.CommitType{Description: "Performance", ChangelogGroup: &perf}
+
+	out, err := (&GoReleaserGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc goreleaserDoc
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+
+	expected := []goreleaserGroup{
+		{Title: "Breaking Changes", Regexp: `^.*?[[:word:]]+(\([^)]+\))?!:.+$`, Order: 0},
+		{Title: "Features", Regexp: `^.*?(feat)(\([^)]+\))?!?:.+$`, Order: 1},
+		{Title: "Bug Fixes", Regexp: `^.*?(fix|perf)(\([^)]+\))?!?:.+$`, Order: 2},
+	}
+	groups := doc.Changelog.Groups
+	if len(groups) != len(expected) {
+		t.Fatalf("expected %d groups, got %+v", len(expected), groups)
+	}
+	for i := range expected {
+		if groups[i] != expected[i] {
+			t.Errorf("group %d: expected %+v, got %+v", i, expected[i], groups[i])
+		}
+	}
+
+	exclude := doc.Changelog.Filters.Exclude
+	if strings.Join(exclude, " ") != `^chore(\([^)]+\))?: ^[[:word:]]+\(deps\)!?:` {
+		t.Errorf("unexpected excludes: %v", exclude)
+	}
+}
+
+func TestGoReleaserMerge(t *testing.T) {
+	existing := []byte(`version: 2
+
+project_name: app # the binary name
+
+changelog:
+  sort: asc
+  filters:
+    include:
+      - "^feat"
+    exclude:
+      - "^docs:"
+  groups:
+    - title: Old
+      regexp: "^old:"
+      order: 0
+    - title: Other
+      order: 999
+
+release:
+  header: |
+    ## Changelog
+`)
+	out, err := (&GoReleaserGenerator{}).Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prefix := "version: 2\n\nproject_name: app # the binary name\n\nchangelog:\n"; !strings.HasPrefix(string(out), prefix) {
+		t.Errorf("layout before changelog not preserved:\n%s", out)
+	}
+	if suffix := "      order: 999\n\nrelease:\n  header: |\n    ## Changelog\n"; !strings.HasSuffix(string(out), suffix) {
+		t.Errorf("layout after changelog not preserved:\n%s", out)
+	}
+	if !strings.Contains(string(out), "# the binary name") {
+		t.Error("comment not preserved")
+	}
+	if !strings.Contains(string(out), "header: |\n    ## Changelog") {
+		t.Errorf("block scalar not preserved:\n%s", out)
+	}
+
+	var doc goreleaserDoc
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+	if doc.ProjectName != "app" || doc.Changelog.Sort != "asc" {
+		t.Error("other settings not preserved")
+	}
+	if len(doc.Changelog.Filters.Include) != 1 {
+		t.Error("filters.include not preserved")
+	}
+	groups := doc.Changelog.Groups
+	if len(groups) != 3 || groups[0].Title != "Features" || groups[1].Title != "Bug Fixes" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+	if groups[2] != (goreleaserGroup{Title: "Other", Order: 999}) {
+		t.Errorf("catch-all group should be kept last, got %+v", groups[2])
+	}
+}
+
+func TestGoReleaserIdempotent(t *testing.T) {
+	g := &GoReleaserGenerator{}
+	cfg := breakingConfig()
+
+	first, err := g.Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("first: %v", err)
+	}
+	second, err := g.Generate(cfg, first)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	third, err := g.Generate(cfg, second)
+	if err != nil {
+		t.Fatalf("third: %v", err)
+	}
+	if !bytes.Equal(first, second) || !bytes.Equal(second, third) {
+		t.Error("goreleaser generator is not idempotent")
+	}
+}
+
+func TestGoReleaserMergeAddsChangelog(t *testing.T) {
+	existing := []byte("version: 2\n\nbuilds:\n  - binary: app\n")
+	out, err := (&GoReleaserGenerator{}).Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(out), string(existing)+"changelog:\n  groups:\n") {
+		t.Errorf("changelog should be added after the existing entries:\n%s", out)
+	}
+	var doc goreleaserDoc
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+	if len(doc.Changelog.Groups) == 0 {
+		t.Error("groups not generated")
+	}
+}
+
+// --- Dependency bot tests ---
+
+func depsConfig() *config.Config {
+	cfg := testConfig()
+	group := "Dependencies"
+	cfg.Types["deps"] = config.CommitType{Description: "Dependency updates", ChangelogGroup: &group, Bump: "patch"}
+	return cfg
+}
+
+func TestRenovateFresh(t *testing.T) {
+	out, err := (&RenovateGenerator{}).Generate(depsConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	if doc["semanticCommitType"] != "deps" || doc["semanticCommits"] != "enabled" {
+		t.Errorf("unexpected settings: %v", doc)
+	}
+	if _, ok := doc["semanticCommitScope"]; ok {
+		t.Error("scope should be left to Renovate when not configured")
+	}
+}
+
+func TestRenovateMerge(t *testing.T) {
+	cfg := testConfig()
+	cfg.Dependencies = &config.Dependencies{Type: "chore", Scope: "deps"}
+	existing := []byte(`{
+  "extends": ["config:recommended"],
+  "semanticCommitType": "build",
+  "packageRules": [{"matchUpdateTypes": ["major"], "automerge": false}]
+}
+`)
+	out, err := (&RenovateGenerator{}).Generate(cfg, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	if doc["semanticCommitType"] != "chore" || doc["semanticCommitScope"] != "deps" {
+		t.Errorf("unexpected settings: %v", doc)
+	}
+	if _, ok := doc["packageRules"]; !ok || doc["extends"] == nil {
+		t.Error("other settings not preserved")
+	}
+}
+
+func TestDependencyBotsSkipWithoutType(t *testing.T) {
+	for _, g := range []Generator{&RenovateGenerator{}, &DependabotGenerator{}} {
+		_, err := g.Generate(testConfig(), nil)
+		var skip *SkipError
+		if !errors.As(err, &skip) || !strings.Contains(skip.Reason, "dependencies.type") {
+			t.Errorf("%s: expected a skip pointing at dependencies.type, got %v", g.Name(), err)
+		}
+	}
+}
+
+func TestDependabotMerge(t *testing.T) {
+	cfg := depsConfig()
+	cfg.Dependencies = &config.Dependencies{Scope: "npm"}
+	existing := []byte(`version: 2
+
+updates:
+  # JavaScript dependencies
+  - package-ecosystem: npm
+    directory: /
+    schedule:
+      interval: weekly
+
+  - package-ecosystem: github-actions
+    directory: "/"
+    schedule:
+      interval: monthly
+    commit-message:
+      prefix: "ci"
+      include: scope
+`)
+	out, err := (&DependabotGenerator{}).Generate(cfg, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `version: 2
+
+updates:
+  # JavaScript dependencies
+  - package-ecosystem: npm
+    directory: /
+    schedule:
+      interval: weekly
+    commit-message:
+      prefix: deps(npm)
+
+  - package-ecosystem: github-actions
+    directory: "/"
+    schedule:
+      interval: monthly
+    commit-message:
+      prefix: "deps(npm)"
+      include: scope
+`
+	if string(out) != want {
+		t.Errorf("only the prefixes should change:\n%s", out)
+	}
+
+	var doc struct {
+		Version int `yaml:"version"`
+		Updates []struct {
+			Ecosystem     string            `yaml:"package-ecosystem"`
+			Schedule      map[string]string `yaml:"schedule"`
+			CommitMessage map[string]string `yaml:"commit-message"`
+		} `yaml:"updates"`
+	}
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+	if doc.Version != 2 || len(doc.Updates) != 2 {
+		t.Fatalf("unexpected document: %+v", doc)
+	}
+	for _, u := range doc.Updates {
+		if u.CommitMessage["prefix"] != "deps(npm)" {
+			t.Errorf("%s: expected prefix deps(npm), got %q", u.Ecosystem, u.CommitMessage["prefix"])
+		}
+	}
+	if doc.Updates[1].CommitMessage["include"] != "scope" || doc.Updates[1].Schedule["interval"] != "monthly" {
+		t.Error("other update settings not preserved")
+	}
+}
+
+func TestDependabotSkipsWithoutFile(t *testing.T) {
+	_, err := (&DependabotGenerator{}).Generate(depsConfig(), nil)
+	var skip *SkipError
+	if !errors.As(err, &skip) {
+		t.Fatalf("expected a SkipError without an existing file, got %v", err)
+	}
+}
+
+func TestDependabotIdempotent(t *testing.T) {
+	g := &DependabotGenerator{}
+	cfg := depsConfig()
+	existing := []byte("version: 2\nupdates:\n  - package-ecosystem: gomod\n    directory: /\n")
+
+	first, err := g.Generate(cfg, existing)
+	if err != nil {
+		t.Fatalf("first: %v", err)
+	}
+	second, err := g.Generate(cfg, first)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Error("dependabot generator is not idempotent")
+	}
+}
+
+// --- GitHub release notes tests ---
+
+type githubReleaseDoc struct {
+	Changelog struct {
+		Exclude struct {
+			Labels  []string `yaml:"labels"`
+			Authors []string `yaml:"authors"`
+		} `yaml:"exclude"`
+		Categories []releaseCategory `yaml:"categories"`
+	} `yaml:"changelog"`
+}
+
+func TestGitHubReleaseNotesFresh(t *testing.T) {
+	cfg := breakingConfig()
+	perf := "Bug Fixes"
+	cfg.Types["perf"] = config.CommitType{Description: "Performance", ChangelogGroup: &perf}
+
+	out, err := (&GitHubReleaseNotesGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc githubReleaseDoc
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+
+	categories := doc.Changelog.Categories
+	if len(categories) != 3 {
+		t.Fatalf("expected 3 categories, got %+v", categories)
+	}
+	if categories[0].Title != "Breaking Changes" || strings.Join(categories[0].Labels, ",") != "breaking" {
+		t.Errorf("unexpected breaking category: %+v", categories[0])
+	}
+	if categories[2].Title != "Bug Fixes" || strings.Join(categories[2].Labels, ",") != "fix,perf" {
+		t.Errorf("types sharing a group should share a category: %+v", categories[2])
+	}
+	if strings.Join(doc.Changelog.Exclude.Labels, ",") != "chore" {
+		t.Errorf("unexpected excluded labels: %v", doc.Changelog.Exclude.Labels)
+	}
+}
+
+func TestGitHubReleaseNotesMerge(t *testing.T) {
+	existing := []byte(`# Release notes config
+changelog:
+  exclude:
+    labels:
+      - ignore-for-release
+    authors:
+      - dependabot
+  categories:
+    - title: Old
+      labels:
+        - old
+    - title: Other Changes
+      labels:
+        - "*"
+`)
+	out, err := (&GitHubReleaseNotesGenerator{}).Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), "# Release notes config") {
+		t.Error("comment not preserved")
+	}
+	var doc githubReleaseDoc
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+	if strings.Join(doc.Changelog.Exclude.Authors, ",") != "dependabot" {
+		t.Error("exclude.authors not preserved")
+	}
+	categories := doc.Changelog.Categories
+	if len(categories) != 3 || categories[0].Title != "Features" || categories[2].Title != "Other Changes" {
+		t.Errorf("expected generated categories followed by the catch-all, got %+v", categories)
+	}
+}
+
+func TestGitHubLabels(t *testing.T) {
+	g := &GitHubLabelsGenerator{}
+	out, err := g.Generate(breakingConfig(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var labels []githubLabel
+	if err := yaml.Unmarshal(out, &labels); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+	expected := []githubLabel{
+		{Name: "breaking", Color: "b60205", Description: "Breaking change"},
+		{Name: "feat", Color: "0e8a16", Description: "A new feature"},
+		{Name: "fix", Color: "1d76db", Description: "A bug fix"},
+		{Name: "chore", Color: "ededed", Description: "Other changes"},
+	}
+	if len(labels) != len(expected) {
+		t.Fatalf("expected %d labels, got %+v", len(expected), labels)
+	}
+	for i := range expected {
+		if labels[i] != expected[i] {
+			t.Errorf("label %d: expected %+v, got %+v", i, expected[i], labels[i])
+		}
+	}
+
+	existing := []byte(`- name: good first issue
+  color: 7057ff
+- name: feat
+  color: a2eeef
+  description: Old description
+`)
+	merged, err := g.Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	labels = nil
+	if err := yaml.Unmarshal(merged, &labels); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+	if len(labels) != 4 || labels[0].Name != "good first issue" {
+		t.Fatalf("unrelated labels should be kept first, got %+v", labels)
+	}
+	if labels[1] != (githubLabel{Name: "feat", Color: "a2eeef", Description: "A new feature"}) {
+		t.Errorf("existing label should keep its color and get the new description, got %+v", labels[1])
+	}
+
+	again, err := g.Generate(testConfig(), merged)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(merged, again) {
+		t.Error("github-labels generator is not idempotent")
+	}
+}
+
+// --- Release Drafter tests ---
+
+func TestReleaseDrafterFresh(t *testing.T) {
+	cfg := aliasConfig()
+	group := "Breaking Changes"
+	cfg.Breaking = &config.Breaking{ChangelogGroup: &group}
+
+	out, err := (&ReleaseDrafterGenerator{}).Generate(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc struct {
+		Categories      []releaseCategory    `yaml:"categories"`
+		ExcludeLabels   []string             `yaml:"exclude-labels"`
+		VersionResolver map[string]yaml.Node `yaml:"version-resolver"`
+		Autolabeler     []autolabelRule      `yaml:"autolabeler"`
+	}
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+
+	if len(doc.Categories) != 3 || doc.Categories[0].Title != "Breaking Changes" {
+		t.Errorf("unexpected categories: %+v", doc.Categories)
+	}
+	if strings.Join(doc.ExcludeLabels, ",") != "chore" {
+		t.Errorf("unexpected exclude-labels: %v", doc.ExcludeLabels)
+	}
+
+	levels := map[string]string{}
+	for level, node := range doc.VersionResolver {
+		if level == "default" {
+			levels[level] = node.Value
+			continue
+		}
+		var list labelList
+		if err := node.Decode(&list); err != nil {
+			t.Fatalf("decoding %s: %v", level, err)
+		}
+		levels[level] = strings.Join(list.Labels, ",")
+	}
+	expected := map[string]string{"major": "breaking", "minor": "feat", "patch": "fix", "default": "patch"}
+	for level, labels := range expected {
+		if levels[level] != labels {
+			t.Errorf("version-resolver %s: expected %q, got %q", level, labels, levels[level])
+		}
+	}
+
+	rules := doc.Autolabeler
+	if len(rules) != 4 {
+		t.Fatalf("expected 4 autolabeler rules, got %+v", rules)
+	}
+	if rules[0].Label != "breaking" || rules[0].Title[0] != `/^[a-z]+(\(.*\))?!:/` || rules[0].Body[0] != "/^BREAKING[ -]CHANGE/m" {
+		t.Errorf("unexpected breaking rule: %+v", rules[0])
+	}
+	if rules[2].Label != "fix" || strings.Join(rules[2].Title, " ") != `/^fix(\(.*\))?!?:/ /^bugfix(\(.*\))?!?:/` {
+		t.Errorf("aliases should add title patterns for their type: %+v", rules[2])
+	}
+}
+
+func TestReleaseDrafterMerge(t *testing.T) {
+	existing := []byte(`name-template: v$RESOLVED_VERSION # keep
+categories:
+  - title: Old
+    labels: [old]
+version-resolver:
+  major:
+    labels: [major]
+  default: minor
+autolabeler:
+  - label: docs-site
+    files:
+      - "site/**"
+  - label: feat
+    title: ["/old/"]
+template: |
+  ## Changes
+  $CHANGES
+`)
+	g := &ReleaseDrafterGenerator{}
+	out, err := g.Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), "# keep") || !strings.Contains(string(out), "## Changes") {
+		t.Errorf("comments and other settings not preserved:\n%s", out)
+	}
+
+	var doc struct {
+		VersionResolver map[string]any  `yaml:"version-resolver"`
+		Autolabeler     []autolabelRule `yaml:"autolabeler"`
+	}
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+	if doc.VersionResolver["default"] != "minor" {
+		t.Error("version-resolver default not preserved")
+	}
+	if _, ok := doc.VersionResolver["major"]; ok {
+		t.Error("major labels should be removed when no type bumps major")
+	}
+	labels := make([]string, len(doc.Autolabeler))
+	for i, r := range doc.Autolabeler {
+		labels[i] = r.Label
+	}
+	if strings.Join(labels, ",") != "feat,fix,chore,docs-site" {
+		t.Errorf("expected generated rules then custom ones, got %v", labels)
+	}
+
+	again, err := g.Generate(testConfig(), out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Error("release-drafter generator is not idempotent")
+	}
+}
+
+func TestReleaseDrafterFreshMatchesMerge(t *testing.T) {
+	g := &ReleaseDrafterGenerator{}
+	first, err := g.Generate(testConfig(), nil)
+	if err != nil {
+		t.Fatalf("first: %v", err)
+	}
+	second, err := g.Generate(testConfig(), first)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Errorf("merging into fresh output should not change it:\n%s\n---\n%s", first, second)
+	}
+}
+
+// --- Semantic PR title tests ---
+
+func TestSemanticPRMerge(t *testing.T) {
+	existing := []byte(`name: Lint PR
+on: pull_request_target
+
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          types: untouched
+
+      - uses: amannn/action-semantic-pull-request@0723387faaf9b38adef4775cd42cfd5155ed6017 # v5
+        env:
+          GITHUB_TOKEN: ${{ secrets.GITHUB_TOKEN }}
+        with:
+          requireScope: false # keep
+          types: |
+            old
+          scopes: |
+            stale
+
+  other:
+    runs-on: ubuntu-latest
+`)
+	cfg := aliasConfig()
+	g := &SemanticPRGenerator{}
+	out, err := g.Generate(cfg, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := string(out)
+	for _, want := range []string{"# v5", "requireScope: false # keep", "types: untouched"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected %q to be preserved:\n%s", want, s)
+		}
+	}
+
+	var doc struct {
+		Jobs map[string]struct {
+			Steps []struct {
+				With map[string]any `yaml:"with"`
+			} `yaml:"steps"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("output not valid YAML: %v", err)
+	}
+	with := doc.Jobs["lint"].Steps[1].With
+	if with["types"] != "feat\nfeature\nfix\nchore\n" {
+		t.Errorf("types should match commitlint's type-enum, got %q", with["types"])
+	}
+	if _, ok := with["scopes"]; ok {
+		t.Errorf("scopes should be dropped when only excluded scopes exist, got %q", with["scopes"])
+	}
+	prefix := existing[:bytes.Index(existing, []byte("          types: |"))]
+	suffix := existing[bytes.Index(existing, []byte("\n  other:")):]
+	if !bytes.HasPrefix(out, prefix) || !bytes.HasSuffix(out, suffix) {
+		t.Errorf("only the types and scopes inputs should change:\n%s", out)
+	}
+
+	again, err := g.Generate(cfg, out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Error("semantic-pr generator is not idempotent")
+	}
+}
+
+func TestSemanticPRSkipsWithoutWorkflow(t *testing.T) {
+	_, err := (&SemanticPRGenerator{}).Generate(testConfig(), nil)
+	var skip *SkipError
+	if !errors.As(err, &skip) {
+		t.Fatalf("expected a SkipError when no workflow uses the action, got %v", err)
+	}
+}
+
+func TestSemanticPRScopes(t *testing.T) {
+	cfg := testConfig()
+	cfg.Scopes = map[string]config.Scope{"api": {}, "ui": {}}
+	existing := []byte("jobs:\n  pr-title:\n    steps:\n      - uses: amannn/action-semantic-pull-request@v5\n        with:\n          types: |\n            feat\n")
+	out, err := (&SemanticPRGenerator{}).Generate(cfg, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// excluded_scopes (deps) only hides commits from changelogs
+	if !strings.Contains(string(out), "scopes: |\n            api\n            ui\n") {
+		t.Errorf("scopes should list the configured scopes only:\n%s", out)
+	}
+
+	again, err := (&SemanticPRGenerator{}).Generate(cfg, out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Error("semantic-pr generator is not idempotent")
+	}
+}
+
+func TestSemanticPRNoStep(t *testing.T) {
+	_, err := (&SemanticPRGenerator{}).Generate(testConfig(), []byte("jobs:\n  build:\n    steps: []\n"))
+	if err == nil || !strings.Contains(err.Error(), "amannn/action-semantic-pull-request") {
+		t.Errorf("expected missing step error, got %v", err)
+	}
+}
+
+func TestSemanticPRLocate(t *testing.T) {
+	dir := t.TempDir()
+	workflows := filepath.Join(dir, ".github", "workflows")
+	if err := os.MkdirAll(workflows, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	g := &SemanticPRGenerator{}
+
+	target, err := Resolve(g, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.Path != filepath.Join(dir, g.FileName()) {
+		t.Errorf("expected the default file name, got %s", target.Path)
+	}
+
+	files := map[string]string{
+		"ci.yml": "jobs:\n  test:\n    steps:\n      - uses: actions/checkout@v4\n",
+		// mentions the action only in a comment
+		"a.yml":       "# amannn/action-semantic-pull-request\njobs: {}\n",
+		"titles.yaml": "jobs:\n  lint:\n    steps:\n      - uses: amannn/action-semantic-pull-request@v5\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(workflows, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	target, err = Resolve(g, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.Path != filepath.Join(workflows, "titles.yaml") {
+		t.Errorf("expected titles.yaml, got %s", target.Path)
+	}
+}
+
+// --- Location tests ---
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDefaultLocationIsFileName(t *testing.T) {
+	for _, g := range All() {
+		loc := defaultLocation(locations(g))
+		if loc.Path != g.FileName() || loc.Format != formatOf(g.FileName()) || loc.Section != nil || loc.Key != nil {
+			t.Errorf("%s: default location is %+v, want %s", g.Name(), loc, g.FileName())
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	g := &ChangieGenerator{}
+	dir := t.TempDir()
+
+	target, err := Resolve(g, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.Path != filepath.Join(dir, ".changie.yaml") {
+		t.Errorf("expected the default file name, got %s", target.Path)
+	}
+
+	writeFiles(t, dir, map[string]string{".changie.yml": "changesDir: .changes\n"})
+	target, err = Resolve(g, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.Path != filepath.Join(dir, ".changie.yml") {
+		t.Errorf("expected the existing .changie.yml, got %s", target.Path)
+	}
+}
+
+func TestResolveSection(t *testing.T) {
+	g := &CommitlintGenerator{}
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{"package.json": `{"name": "demo"}`})
+	target, err := Resolve(g, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.Path != filepath.Join(dir, ".commitlintrc.json") {
+		t.Errorf("package.json without a commitlint key should be ignored, got %s", target.Path)
+	}
+
+	writeFiles(t, dir, map[string]string{"package.json": `{"name": "demo", "commitlint": {}}`})
+	target, err = Resolve(g, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.Path != filepath.Join(dir, "package.json") || !slices.Equal(target.Section, []string{"commitlint"}) {
+		t.Errorf("expected the commitlint section of package.json, got %+v", target)
+	}
+}
+
+func TestResolveSniffsFormat(t *testing.T) {
+	g := &SemanticReleaseGenerator{}
+	for content, want := range map[string]string{
+		`{"branches": ["main"]}`: formatJSON,
+		"branches:\n  - main\n":  formatYAML,
+	} {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{".releaserc": content})
+		target, err := Resolve(g, dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if target.Format != want {
+			t.Errorf("%q: expected format %s, got %s", content, want, target.Format)
+		}
+	}
+}
+
+func TestTargetGenerateSection(t *testing.T) {
+	g := &CommitlintGenerator{}
+	dir := t.TempDir()
+	existing := "{\n    \"name\": \"demo\",\n    \"commitlint\": {\n        \"extends\": [\"@commitlint/config-conventional\"]\n    },\n    \"private\": true\n}\n"
+	writeFiles(t, dir, map[string]string{"package.json": existing})
+
+	target, err := Resolve(g, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := target.Generate(g, testConfig(), []byte(existing))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	doc, err := parseJSONObject(out)
+	if err != nil {
+		t.Fatalf("invalid output: %v", err)
+	}
+	if !slices.Equal(doc.keys, []string{"name", "commitlint", "private"}) {
+		t.Errorf("package.json keys changed: %v", doc.keys)
+	}
+	section := doc.values["commitlint"].(*orderedMap)
+	if _, ok := section.values["extends"]; !ok {
+		t.Error("extends should be kept")
+	}
+	if _, ok := section.Object("rules").values["type-enum"]; !ok {
+		t.Error("type-enum should be set in the commitlint section")
+	}
+	if !strings.Contains(string(out), "\n    \"name\"") {
+		t.Errorf("indentation should be kept:\n%s", out)
+	}
+
+	again, err := target.Generate(g, testConfig(), out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Error("merge should be idempotent")
+	}
+}
+
+func TestTargetGenerateYAML(t *testing.T) {
+	g := &SemanticReleaseGenerator{}
+	dir := t.TempDir()
+	existing := `# release config
+branches:
+  - main # primary
+plugins:
+  - - "@semantic-release/commit-analyzer"
+    - preset: conventionalcommits # keep
+      releaseRules: []
+  - "@semantic-release/github"
+`
+	writeFiles(t, dir, map[string]string{".releaserc.yml": existing})
+
+	target, err := Resolve(g, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := target.Generate(g, testConfig(), []byte(existing))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{"# release config\n", "- main # primary\n", "preset: conventionalcommits # keep\n", "- type: feat\n", "release: minor\n"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output should contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(string(out), "{") {
+		t.Errorf("output should stay YAML:\n%s", out)
+	}
+
+	again, err := target.Generate(g, testConfig(), out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Errorf("merge should be idempotent:\n%s\n---\n%s", out, again)
+	}
+}
+
+func TestTargetGenerateYAMLNumbers(t *testing.T) {
+	g := &CommitlintGenerator{}
+	existing := "rules:\n  header-max-length: [1, always, 72]\n"
+	target := Target{Location: Location{Path: ".commitlintrc.yaml", Format: formatYAML}}
+	out, err := target.Generate(g, testConfig(), []byte(existing))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), "header-max-length: [2, always, 100]") {
+		t.Errorf("numbers should stay unquoted and flow style kept:\n%s", out)
+	}
+}
+
+func TestTargetGenerateTOMLConversion(t *testing.T) {
+	target := Target{Location: Location{Path: ".commitlintrc.toml", Format: formatTOML}}
+	if _, err := target.Generate(&CommitlintGenerator{}, testConfig(), []byte("a = 1\n")); err == nil {
+		t.Error("expected an error converting TOML to JSON")
+	}
+}
+
+func TestEmbedJSONKeepsHost(t *testing.T) {
+	host := `{
+  "name": "demo",
+  "scripts": {"test": "x"},
+  "release": {"branches": ["main"]},
+  "files": [ "dist" ] // published
+}
+`
+	target := Target{Location: Location{Path: "package.json", Format: formatJSON, Section: []string{"release"}}}
+	g := &SemanticReleaseGenerator{}
+	out, err := target.Generate(g, testConfig(), []byte(host))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	prefix := "{\n  \"name\": \"demo\",\n  \"scripts\": {\"test\": \"x\"},\n  \"release\": {\n    \"branches\": [\"main\"],\n    \"plugins\": [\n"
+	suffix := "\n  },\n  \"files\": [ \"dist\" ] // published\n}\n"
+	if !strings.HasPrefix(string(out), prefix) || !strings.HasSuffix(string(out), suffix) {
+		t.Errorf("only the release key should change:\n%s", out)
+	}
+
+	again, err := target.Generate(g, testConfig(), out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Error("merge should be idempotent")
+	}
+}
+
+func TestEmbedJSONReleaseWithoutPlugins(t *testing.T) {
+	host := "{\n\t\"name\": \"demo\",\n\t\"release\": {\n\t\t\"branches\": [\"main\", \"next\"],\n\t\t\"repositoryUrl\": \"https://example.com/demo.git\",\n\t\t\"tagFormat\": \"v${version}\"\n\t}\n}\n"
+	target := Target{Location: Location{Path: "package.json", Format: formatJSON, Section: []string{"release"}}}
+	g := &SemanticReleaseGenerator{}
+	out, err := target.Generate(g, testConfig(), []byte(host))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	doc, err := parseJSONObject(out)
+	if err != nil {
+		t.Fatalf("invalid output: %v", err)
+	}
+	release := doc.values["release"].(*orderedMap)
+	if !slices.Equal(release.keys, []string{"branches", "repositoryUrl", "tagFormat", "plugins"}) {
+		t.Errorf("existing release settings should be kept: %v", release.keys)
+	}
+	if !strings.Contains(string(out), "\n\t\t\"plugins\": [\n\t\t\t[\n") {
+		t.Errorf("plugins should use the host's indentation:\n%s", out)
+	}
+
+	// check compares a second run with the file on disk
+	again, err := target.Generate(g, testConfig(), out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Errorf("check should pass right after generate:\nfirst:\n%s\nsecond:\n%s", out, again)
+	}
+}
+
+func TestEmbedJSONMissingSection(t *testing.T) {
+	tests := []struct {
+		host, want string
+	}{
+		{"{\n    \"name\": \"demo\"\n}\n", "{\n    \"name\": \"demo\",\n    \"config\": {\n        \"tool\": {\n            \"a\": 1\n        }\n    }\n}\n"},
+		{"{}\n", "{\n  \"config\": {\n    \"tool\": {\n      \"a\": 1\n    }\n  }\n}\n"},
+	}
+	for _, tt := range tests {
+		e, err := newJSONEmbedding([]byte(tt.host), []string{"config", "tool"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if doc, _ := e.doc(); doc != nil {
+			t.Errorf("expected no document, got %s", doc)
+		}
+		out, err := e.replace([]byte(`{"a": 1}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(out) != tt.want {
+			t.Errorf("got:\n%s\nwant:\n%s", out, tt.want)
+		}
+	}
+}
+
+func TestEmbedJSONNotObject(t *testing.T) {
+	if _, err := newJSONEmbedding([]byte(`{"release": "./release.config.js"}`), []string{"release"}); err == nil {
+		t.Error("expected an error for a non-object section")
+	}
+}
+
+func TestEmbedTOMLSection(t *testing.T) {
+	host := `[package]
+name = "demo"   # the crate
+
+[package.metadata.git-cliff.changelog]
+# keep this
+header = "# Changelog"
+
+[package.metadata.git-cliff.git]
+conventional_commits = true
+commit_parsers = []
+
+[profile.release]
+lto = true
+`
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"Cargo.toml": host})
+	g := &CliffGenerator{}
+	target, err := Resolve(g, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.Path != filepath.Join(dir, "Cargo.toml") {
+		t.Fatalf("expected Cargo.toml, got %s", target.Path)
+	}
+
+	out, err := target.Generate(g, testConfig(), []byte(host))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	before, after, _ := strings.Cut(host, "commit_parsers = []")
+	if !strings.HasPrefix(string(out), before+"commit_parsers = [\n") || !strings.HasSuffix(string(out), "]"+after) {
+		t.Errorf("only commit_parsers should change:\n%s", out)
+	}
+	if !strings.Contains(string(out), `{ message = '^feat', group = 'Features' }`) {
+		t.Errorf("expected generated parsers:\n%s", out)
+	}
+
+	again, err := target.Generate(g, testConfig(), out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Error("merge should be idempotent")
+	}
+}
+
+func TestEmbedTOMLRestructure(t *testing.T) {
+	host := `[project]
+name = "demo"
+
+[tool.git-cliff.git]
+conventional_commits = true
+
+[[tool.git-cliff.git.commit_parsers]]
+message = "^feat"
+group = "Features"
+
+[tool.ruff]
+select = ["E"]
+`
+	target := Target{Location: Location{Path: "pyproject.toml", Format: formatTOML, Section: []string{"tool", "git-cliff"}}}
+	out, err := target.Generate(&CliffGenerator{}, testConfig(), []byte(host))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(out), "[project]\nname = \"demo\"\n\n[tool.git-cliff.git]\nconventional_commits = true\ncommit_parsers = [\n") {
+		t.Errorf("unexpected start:\n%s", out)
+	}
+	if !strings.HasSuffix(string(out), "]\n\n[tool.ruff]\nselect = [\"E\"]\n") {
+		t.Errorf("[tool.ruff] should follow the git-cliff tables unchanged:\n%s", out)
+	}
+	if strings.Contains(string(out), "[[") {
+		t.Errorf("array tables should be replaced:\n%s", out)
+	}
+
+	var doc map[string]any
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("invalid TOML: %v", err)
+	}
+}
+
+func TestEmbedTOMLDottedKeys(t *testing.T) {
+	host := []byte("[tool]\ngit-cliff.git.conventional_commits = true\n")
+	if _, err := newTOMLEmbedding(host, []string{"tool", "git-cliff"}, true); err == nil {
+		t.Error("expected an error for dotted keys outside the section")
+	}
+}
+
+func TestResolveSkipsPyprojectWithoutKey(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"pyproject.toml": "[project]\nname = \"demo\"\n"})
+	g := &CommitizenGenerator{}
+	target, err := Resolve(g, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target.Path != filepath.Join(dir, ".cz.toml") || target.Key != nil {
+		t.Errorf("pyproject.toml without [tool.commitizen] should be left alone, got %+v", target)
+	}
+}
+
+func TestCommitizenPyprojectKeepsOtherTables(t *testing.T) {
+	host := `[project]
+name = "demo"   # keep
+dependencies = ["requests>=2"]
+
+[tool.commitizen]
+name = "cz_conventional_commits"
+version = "1.2.3"
+
+[tool.black]
+line-length = 100
+`
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"pyproject.toml": host})
+	g := &CommitizenGenerator{}
+	target, err := Resolve(g, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := target.Generate(g, testConfig(), []byte(host))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(out), "[project]\nname = \"demo\"   # keep\ndependencies = [\"requests>=2\"]\n\n") {
+		t.Errorf("[project] should be unchanged:\n%s", out)
+	}
+	if !strings.HasSuffix(string(out), "\n\n[tool.black]\nline-length = 100\n") {
+		t.Errorf("[tool.black] should be unchanged:\n%s", out)
+	}
+
+	var doc struct {
+		Tool struct {
+			Commitizen map[string]any `toml:"commitizen"`
+		} `toml:"tool"`
+	}
+	if err := toml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("invalid TOML: %v", err)
+	}
+	if doc.Tool.Commitizen["name"] != "cz_customize" || doc.Tool.Commitizen["version"] != "1.2.3" {
+		t.Errorf("unexpected [tool.commitizen]: %v", doc.Tool.Commitizen)
+	}
+
+	again, err := target.Generate(g, testConfig(), out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Error("merge should be idempotent")
+	}
+}
+
+// --- JSON merge tests ---
+
+func TestJSONMergeKeepsKeyOrder(t *testing.T) {
+	existing := []byte(`{
+    "plugins": [
+        ["@semantic-release/commit-analyzer", {"preset": "conventionalcommits", "releaseRules": []}]
+    ],
+    "tagFormat": "v${version} <beta>",
+    "branches": ["main"],
+    "retries": 1.50
+}
+`)
+	out, err := (&SemanticReleaseGenerator{}).Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := string(out)
+	plugins := strings.Index(s, `"plugins"`)
+	tagFormat := strings.Index(s, `"tagFormat"`)
+	branches := strings.Index(s, `"branches"`)
+	if plugins < 0 || !(plugins < tagFormat && tagFormat < branches) {
+		t.Errorf("top-level key order changed:\n%s", s)
+	}
+	if strings.Index(s, `"preset"`) > strings.Index(s, `"releaseRules"`) {
+		t.Errorf("plugin option order changed:\n%s", s)
+	}
+	for _, want := range []string{"\n    \"tagFormat\": \"v${version} <beta>\",\n", `"retries": 1.50`} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected %q in output:\n%s", want, s)
+		}
+	}
+}
+
+func TestJSONMergeNewKeysSorted(t *testing.T) {
+	existing := []byte("{\n\t\"extends\": \"config:recommended\",\n\t\"$schema\": \"x\"\n}\n")
+	cfg := testConfig()
+	cfg.Types["deps"] = config.CommitType{Description: "Dependency updates"}
+	cfg.Dependencies = &config.Dependencies{Scope: "deps"}
+
+	out, err := (&RenovateGenerator{}).Generate(cfg, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "{\n\t\"extends\": \"config:recommended\",\n\t\"$schema\": \"x\",\n\t\"semanticCommitScope\": \"deps\",\n\t\"semanticCommitType\": \"deps\",\n\t\"semanticCommits\": \"enabled\"\n}\n"
+	if string(out) != expected {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestJSONMergeKeepsUnchangedLayout(t *testing.T) {
+	existing := []byte(`{
+  "extends": ["@commitlint/config-conventional"],
+  "rules": {
+    "type-enum": [2, "always", ["feat", "fix", "chore"]],
+    "header-max-length": [2, "always", 72]
+  },
+  "ignores": [{"pattern": "^WIP"}]
+}
+`)
+	g := &CommitlintGenerator{}
+	out, err := g.Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := string(out)
+	for _, want := range []string{
+		"  \"extends\": [\"@commitlint/config-conventional\"],\n",
+		// set to the value it already had
+		"    \"type-enum\": [2, \"always\", [\"feat\", \"fix\", \"chore\"]],\n",
+		"    \"header-max-length\": [\n      2,\n      \"always\",\n      100\n    ]\n",
+		"  \"ignores\": [{\"pattern\": \"^WIP\"}],\n",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected %q in output:\n%s", want, s)
+		}
+	}
+
+	again, err := g.Generate(testConfig(), out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Errorf("merge should be idempotent:\n%s", again)
+	}
+}
+
+func TestJSONMergeExtras(t *testing.T) {
+	existing := []byte(`{
+  "packages": {"pkg": {"release-type": "go", "changelog-sections": []}},
+  "bootstrap-sha": "abc"
+}
+`)
+	cfg := testConfig()
+	cfg.Generators = map[string]config.GeneratorConfig{
+		"release-please": {Extra: map[string]any{"packages": map[string]any{"pkg": map[string]any{"draft": true}}}},
+	}
+	out, err := (&ReleasePleaseGenerator{}).Generate(cfg, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := string(out)
+	releaseType := strings.Index(s, `"release-type"`)
+	sections := strings.Index(s, `"changelog-sections"`)
+	draft := strings.Index(s, `"draft"`)
+	bootstrap := strings.Index(s, `"bootstrap-sha"`)
+	if !(releaseType < sections && sections < draft && draft < bootstrap) {
+		t.Errorf("expected existing order with extras appended:\n%s", s)
+	}
+}
+
+func TestParseJSONObjectErrors(t *testing.T) {
+	for _, input := range []string{`[1, 2]`, `{"a": 1} {}`, `{"a": }`, `{"a": 1 /* open`, `{"a": 0x}`, `{a b: 1}`} {
+		if _, err := parseJSONObject([]byte(input)); err == nil {
+			t.Errorf("expected an error for %s", input)
+		}
+	}
+}
+
+// --- JSONC / JSON5 tests ---
+
+func TestParseJSON5(t *testing.T) {
+	doc, err := parseJSONObject([]byte(`{
+  unquoted: 'single \'quoted\'',
+  "hex": 0x1F, "inf": -Infinity, "frac": .5, "plus": +1,
+  "multi": "line \
+continued",
+  "nested": {"list": [1, 2,],},
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.values["unquoted"] != "single 'quoted'" {
+		t.Errorf("unexpected string: %q", doc.values["unquoted"])
+	}
+	if doc.values["multi"] != "line continued" {
+		t.Errorf("unexpected line continuation: %q", doc.values["multi"])
+	}
+	out, err := marshalJSON(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{`"unquoted": "single 'quoted'"`, `"hex": 0x1F`, `"inf": -Infinity`, `"frac": .5`, `"plus": +1`} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("expected %s in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestJSONCMergeKeepsComments(t *testing.T) {
+	existing := []byte(`// commitlint settings
+{
+  /* shared base */
+  "extends": ["@commitlint/config-conventional"], // keep
+  "rules": {
+    // local tweak
+    "header-max-length": [2, "always", 72],
+    "type-enum": [2, "always", ["old"]],
+  },
+}
+`)
+	g := &CommitlintGenerator{}
+	out, err := g.Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := string(out)
+	for _, want := range []string{
+		"// commitlint settings\n{\n  /* shared base */\n  \"extends\": [\"@commitlint/config-conventional\"], // keep\n",
+		// changed values are laid out again
+		"    // local tweak\n    \"header-max-length\": [\n      2,\n      \"always\",\n      100\n    ],\n",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected %q in output:\n%s", want, s)
+		}
+	}
+	if strings.Contains(s, `"old"`) {
+		t.Errorf("type-enum should be replaced:\n%s", s)
+	}
+
+	again, err := g.Generate(testConfig(), out)
+	if err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if !bytes.Equal(out, again) {
+		t.Error("commitlint generator is not idempotent with comments")
+	}
+}
+
+func TestJSONCArrayComments(t *testing.T) {
+	existing := []byte(`{
+  "plugins": [
+    // analyzes commits
+    ["@semantic-release/commit-analyzer", {"preset": "conventionalcommits"}],
+    "@semantic-release/github", // publishes
+    // more plugins go here
+  ],
+}
+`)
+	out, err := (&SemanticReleaseGenerator{}).Generate(testConfig(), existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := string(out)
+	for _, want := range []string{
+		"  \"plugins\": [\n    // analyzes commits\n    [\n",
+		"\"releaseRules\"",
+		"    \"@semantic-release/github\" // publishes\n    // more plugins go here\n  ]\n",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected %q in output:\n%s", want, s)
+		}
+	}
 }
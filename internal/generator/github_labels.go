@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/tylerbutler/commit-config-gen/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	Register(&GitHubLabelsGenerator{})
+}
+
+// GitHubLabelsGenerator generates .github/labels.yml, the label set used by
+// the github-release-notes generator, in the name/color/description format
+// read by label-sync tools such as EndBug/label-sync and
+// crazy-max/ghaction-github-labeler.
+type GitHubLabelsGenerator struct{}
+
+func (g *GitHubLabelsGenerator) Name() string     { return "github-labels" }
+func (g *GitHubLabelsGenerator) FileName() string { return ".github/labels.yml" }
+
+// Locations returns both extensions of the labels file.
+func (g *GitHubLabelsGenerator) Locations() []Location {
+	return []Location{
+		{Path: ".github/labels.yml", Format: formatYAML},
+		{Path: ".github/labels.yaml", Format: formatYAML},
+	}
+}
+
+func (g *GitHubLabelsGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
+	labels := buildGitHubLabels(cfg)
+
+	var out []byte
+	var err error
+	if existing != nil {
+		out, err = mergeGitHubLabels(existing, labels)
+	} else {
+		out, err = encodeYAML(labels)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatYAML, cfg.GeneratorExtra(g.Name()))
+}
+
+type githubLabel struct {
+	Name        string `yaml:"name"`
+	Color       string `yaml:"color"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// labelColors picks a label color from the version bump a type triggers.
+var labelColors = map[string]string{
+	"major": "b60205",
+	"minor": "0e8a16",
+	"patch": "1d76db",
+	"":      "ededed",
+	"none":  "ededed",
+}
+
+func buildGitHubLabels(cfg *config.Config) []githubLabel {
+	var labels []githubLabel
+	if cfg.Breaking != nil && cfg.Breaking.ChangelogGroup != nil {
+		labels = append(labels, githubLabel{
+			Name:        breakingLabel,
+			Color:       labelColors[cfg.Breaking.BumpLevel()],
+			Description: "Breaking change",
+		})
+	}
+	for _, name := range cfg.TypeNames() {
+		t := cfg.Types[name]
+		labels = append(labels, githubLabel{
+			Name:        name,
+			Color:       labelColors[t.Bump],
+			Description: t.Description,
+		})
+	}
+	return labels
+}
+
+// mergeGitHubLabels updates the descriptions of existing labels, keeping
+// their colors and any other fields, and appends missing ones. Labels that
+// aren't generated are left alone.
+func mergeGitHubLabels(existing []byte, labels []githubLabel) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(existing, &root); err != nil {
+		return nil, fmt.Errorf("parsing existing labels.yml: %w", err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.SequenceNode {
+		return encodeYAML(labels)
+	}
+	seq := root.Content[0]
+
+	for _, label := range labels {
+		var entry *yaml.Node
+		for _, item := range seq.Content {
+			if item.Kind == yaml.MappingNode {
+				if n := findYAMLKey(item, "name"); n != nil && n.Value == label.Name {
+					entry = item
+					break
+				}
+			}
+		}
+		if entry == nil {
+			var node yaml.Node
+			if err := node.Encode(label); err != nil {
+				return nil, err
+			}
+			seq.Content = append(seq.Content, &node)
+			continue
+		}
+		if label.Description != "" {
+			if err := setYAMLKey(entry, "description", label.Description); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	data, err := encodeYAML(&root)
+	if err != nil {
+		return nil, fmt.Errorf("encoding labels.yml: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/tylerbutler/commit-config-gen/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	Register(&GitHubReleaseNotesGenerator{})
+}
+
+// GitHubReleaseNotesGenerator generates .github/release.yml, which groups
+// GitHub's auto-generated release notes by pull request label. Labels are the
+// type names; see GitHubLabelsGenerator for the matching label set.
+type GitHubReleaseNotesGenerator struct{}
+
+func (g *GitHubReleaseNotesGenerator) Name() string     { return "github-release-notes" }
+func (g *GitHubReleaseNotesGenerator) FileName() string { return ".github/release.yml" }
+
+// Locations returns both extensions GitHub reads.
+func (g *GitHubReleaseNotesGenerator) Locations() []Location {
+	return []Location{
+		{Path: ".github/release.yml", Format: formatYAML},
+		{Path: ".github/release.yaml", Format: formatYAML},
+	}
+}
+
+func (g *GitHubReleaseNotesGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
+	categories := buildReleaseCategories(cfg)
+	exclude := hiddenTypeNames(cfg)
+
+	var out []byte
+	var err error
+	if existing != nil {
+		out, err = mergeGitHubReleaseNotes(existing, categories, exclude)
+	} else {
+		out, err = freshGitHubReleaseNotes(categories, exclude)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatYAML, cfg.GeneratorExtra(g.Name()))
+}
+
+// breakingLabel marks pull requests with breaking changes.
+const breakingLabel = "breaking"
+
+type releaseCategory struct {
+	Title  string   `yaml:"title"`
+	Labels []string `yaml:"labels"`
+}
+
+// buildReleaseCategories returns one category per changelog group, in type
+// order, labelled with the names of the types in that group.
+func buildReleaseCategories(cfg *config.Config) []releaseCategory {
+	var categories []releaseCategory
+	if cfg.Breaking != nil && cfg.Breaking.ChangelogGroup != nil {
+		categories = append(categories, releaseCategory{Title: *cfg.Breaking.ChangelogGroup, Labels: []string{breakingLabel}})
+	}
+	for _, name := range cfg.TypeNames() {
+		t := cfg.Types[name]
+		if t.ChangelogGroup == nil {
+			continue
+		}
+		i := slices.IndexFunc(categories, func(c releaseCategory) bool { return c.Title == *t.ChangelogGroup })
+		if i < 0 {
+			categories = append(categories, releaseCategory{Title: *t.ChangelogGroup})
+			i = len(categories) - 1
+		}
+		categories[i].Labels = append(categories[i].Labels, name)
+	}
+	return categories
+}
+
+func hiddenTypeNames(cfg *config.Config) []string {
+	var hidden []string
+	for _, name := range cfg.TypeNames() {
+		if cfg.Types[name].ChangelogGroup == nil {
+			hidden = append(hidden, name)
+		}
+	}
+	return hidden
+}
+
+func freshGitHubReleaseNotes(categories []releaseCategory, exclude []string) ([]byte, error) {
+	type excludeSection struct {
+		Labels []string `yaml:"labels"`
+	}
+	doc := struct {
+		Changelog struct {
+			Exclude    excludeSection    `yaml:"exclude"`
+			Categories []releaseCategory `yaml:"categories"`
+		} `yaml:"changelog"`
+	}{}
+	doc.Changelog.Exclude.Labels = exclude
+	doc.Changelog.Categories = categories
+	return encodeYAML(doc)
+}
+
+// mergeGitHubReleaseNotes replaces changelog.categories and
+// changelog.exclude.labels. Existing catch-all categories (labels: ["*"]) are
+// kept after the generated ones.
+func mergeGitHubReleaseNotes(existing []byte, categories []releaseCategory, exclude []string) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(existing, &root); err != nil {
+		return nil, fmt.Errorf("parsing existing release.yml: %w", err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return freshGitHubReleaseNotes(categories, exclude)
+	}
+
+	changelog := ensureYAMLMapping(root.Content[0], "changelog")
+
+	all := make([]any, 0, len(categories))
+	for _, c := range categories {
+		all = append(all, c)
+	}
+	if existingCategories := findYAMLKey(changelog, "categories"); existingCategories != nil && existingCategories.Kind == yaml.SequenceNode {
+		for _, c := range existingCategories.Content {
+			if c.Kind == yaml.MappingNode && isCatchAllCategory(c) {
+				all = append(all, c)
+			}
+		}
+	}
+	if err := setYAMLKey(changelog, "categories", all); err != nil {
+		return nil, err
+	}
+
+	excludeNode := ensureYAMLMapping(changelog, "exclude")
+	if err := setYAMLKey(excludeNode, "labels", exclude); err != nil {
+		return nil, err
+	}
+
+	data, err := encodeYAML(&root)
+	if err != nil {
+		return nil, fmt.Errorf("encoding release.yml: %w", err)
+	}
+	return data, nil
+}
+
+func isCatchAllCategory(category *yaml.Node) bool {
+	labels := findYAMLKey(category, "labels")
+	if labels == nil || labels.Kind != yaml.SequenceNode {
+		return false
+	}
+	for _, l := range labels.Content {
+		if l.Value == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/tylerbutler/commit-config-gen/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	Register(&GoReleaserGenerator{})
+}
+
+// GoReleaserGenerator generates the changelog section of .goreleaser.yaml.
+type GoReleaserGenerator struct{}
+
+func (g *GoReleaserGenerator) Name() string     { return "goreleaser" }
+func (g *GoReleaserGenerator) FileName() string { return ".goreleaser.yaml" }
+
+// Locations returns the file names GoReleaser reads.
+func (g *GoReleaserGenerator) Locations() []Location {
+	return []Location{
+		{Path: ".goreleaser.yaml", Format: formatYAML},
+		{Path: ".goreleaser.yml", Format: formatYAML},
+		{Path: "goreleaser.yaml", Format: formatYAML},
+		{Path: "goreleaser.yml", Format: formatYAML},
+	}
+}
+
+func (g *GoReleaserGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
+	groups := buildGoReleaserGroups(cfg)
+	exclude := buildGoReleaserExcludes(cfg)
+
+	var out []byte
+	var err error
+	if existing != nil {
+		out, err = mergeGoReleaser(existing, groups, exclude)
+	} else {
+		out, err = freshGoReleaser(groups, exclude)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatYAML, cfg.GeneratorExtra(g.Name()))
+}
+
+type goreleaserGroup struct {
+	Title  string `yaml:"title"`
+	Regexp string `yaml:"regexp"`
+	Order  int    `yaml:"order"`
+}
+
+// goreleaserScope matches an optional "(scope)" after the type.
+const goreleaserScope = `(\([^)]+\))?`
+
+// buildGoReleaserGroups returns one group per changelog group, in type order.
+// Types sharing a group share one regexp. GoReleaser only sees the subject
+// line, so breaking changes are matched by "!" alone.
+func buildGoReleaserGroups(cfg *config.Config) []goreleaserGroup {
+	var groups []goreleaserGroup
+	if cfg.Breaking != nil && cfg.Breaking.ChangelogGroup != nil && (cfg.Breaking.Types == nil || len(cfg.Breaking.Types) > 0) {
+		typePattern := `[[:word:]]+`
+		if cfg.Breaking.Types != nil {
+			var allowed []string
+			for _, name := range cfg.TypeNames() {
+				if cfg.Breaking.AllowsBang(name) {
+					allowed = append(allowed, typeAndAliases(cfg, name)...)
+				}
+			}
+			typePattern = "(" + strings.Join(allowed, "|") + ")"
+		}
+		groups = append(groups, goreleaserGroup{
+			Title:  *cfg.Breaking.ChangelogGroup,
+			Regexp: `^.*?` + typePattern + goreleaserScope + `!:.+$`,
+		})
+	}
+
+	var titles []string
+	members := map[string][]string{}
+	for _, name := range cfg.TypeNames() {
+		t := cfg.Types[name]
+		if t.ChangelogGroup == nil {
+			continue
+		}
+		title := *t.ChangelogGroup
+		if _, ok := members[title]; !ok {
+			titles = append(titles, title)
+		}
+		members[title] = append(members[title], typeAndAliases(cfg, name)...)
+	}
+	for _, title := range titles {
+		groups = append(groups, goreleaserGroup{
+			Title:  title,
+			Regexp: `^.*?(` + strings.Join(members[title], "|") + `)` + goreleaserScope + `!?:.+$`,
+		})
+	}
+
+	for i := range groups {
+		groups[i].Order = i
+	}
+	return groups
+}
+
+// buildGoReleaserExcludes returns filters for hidden types and scopes. Breaking
+// commits of hidden types are not excluded, so they still reach a breaking group.
+func buildGoReleaserExcludes(cfg *config.Config) []string {
+	var exclude []string
+	for _, name := range cfg.TypeNames() {
+		if cfg.Types[name].ChangelogGroup == nil {
+			for _, n := range typeAndAliases(cfg, name) {
+				exclude = append(exclude, "^"+n+goreleaserScope+":")
+			}
+		}
+	}
+	for _, scope := range cfg.HiddenScopes() {
+		exclude = append(exclude, `^[[:word:]]+\(`+scope+`\)!?:`)
+	}
+	return exclude
+}
+
+func typeAndAliases(cfg *config.Config, name string) []string {
+	names := []string{name}
+	for _, alias := range cfg.Types[name].Aliases {
+		names = append(names, alias.Name)
+	}
+	return names
+}
+
+func freshGoReleaser(groups []goreleaserGroup, exclude []string) ([]byte, error) {
+	doc := map[string]any{
+		"version": 2,
+		"changelog": map[string]any{
+			"groups": groups,
+			"filters": map[string]any{
+				"exclude": exclude,
+			},
+		},
+	}
+	return encodeYAML(doc)
+}
+
+// mergeGoReleaser replaces changelog.groups and changelog.filters.exclude.
+// Existing groups without a regexp are catch-alls and are kept after the
+// generated ones. Only the changelog entry is rewritten, so the rest of the
+// file keeps its layout.
+func mergeGoReleaser(existing []byte, groups []goreleaserGroup, exclude []string) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(existing, &root); err != nil {
+		return nil, fmt.Errorf("parsing existing .goreleaser.yaml: %w", err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return freshGoReleaser(groups, exclude)
+	}
+	doc := root.Content[0]
+	if len(doc.Content) == 0 {
+		return freshGoReleaser(groups, exclude)
+	}
+
+	changelog := findYAMLKey(doc, "changelog")
+	if changelog == nil || changelog.Kind != yaml.MappingNode {
+		changelog = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	allGroups := make([]any, 0, len(groups))
+	for _, group := range groups {
+		allGroups = append(allGroups, group)
+	}
+	if existingGroups := findYAMLKey(changelog, "groups"); existingGroups != nil && existingGroups.Kind == yaml.SequenceNode {
+		for _, group := range existingGroups.Content {
+			if group.Kind == yaml.MappingNode && findYAMLKey(group, "regexp") == nil {
+				allGroups = append(allGroups, group)
+			}
+		}
+	}
+	if err := setYAMLKey(changelog, "groups", allGroups); err != nil {
+		return nil, err
+	}
+
+	filters := ensureYAMLMapping(changelog, "filters")
+	if err := setYAMLKey(filters, "exclude", exclude); err != nil {
+		return nil, err
+	}
+	// a flow-style changelog would be written back on one line
+	changelog.Style = 0
+
+	if doc.Style&yaml.FlowStyle != 0 {
+		if err := setYAMLKey(doc, "changelog", changelog); err != nil {
+			return nil, err
+		}
+		data, err := encodeYAML(&root)
+		if err != nil {
+			return nil, fmt.Errorf("encoding .goreleaser.yaml: %w", err)
+		}
+		return data, nil
+	}
+	edit, err := setYAMLEntry(existing, doc, "changelog", changelog)
+	if err != nil {
+		return nil, fmt.Errorf("encoding .goreleaser.yaml: %w", err)
+	}
+	return applyTextEdits(existing, []textEdit{edit}), nil
+}
+
+// encodeYAML marshals v with the two-space indent GoReleaser configs use.
+func encodeYAML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
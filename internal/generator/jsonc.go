@@ -0,0 +1,462 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// parseJSONObject parses a JSON object, keeping the order of keys in every
+// nested object, the exact text of numbers, and comments. JSONC and JSON5
+// input (comments, trailing commas, unquoted keys, single-quoted strings) is
+// accepted; it is written back as JSON with the comments in place.
+func parseJSONObject(data []byte) (*orderedMap, error) {
+	return (&jsonParser{data: data}).document()
+}
+
+// parseJSONLayout is parseJSONObject for a document that will be written at a
+// different indent: it keeps no source text, so every value is laid out again.
+func parseJSONLayout(data []byte) (*orderedMap, error) {
+	return (&jsonParser{data: data, layout: true}).document()
+}
+
+func (p *jsonParser) document() (*orderedMap, error) {
+	head := p.skip()
+	if p.peek() != '{' {
+		return nil, p.errorf("expected a JSON object")
+	}
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	obj := value.(*orderedMap)
+	obj.head = commentTexts(head)
+	obj.tail = commentTexts(p.skip())
+	if p.pos < len(p.data) {
+		return nil, p.errorf("unexpected data after the top-level object")
+	}
+	return obj, nil
+}
+
+type jsonParser struct {
+	data    []byte
+	pos     int
+	lastEnd int // end of the last value or comma, for telling inline comments apart
+	// arraySource is the source of the last array parsed, for the caller to
+	// keep next to it.
+	arraySource *jsonSource
+	layout      bool // keep no sources
+}
+
+type jsonComment struct {
+	text   string
+	inline bool // starts on the line where the previous value or comma ended
+}
+
+func commentTexts(comments []jsonComment) []string {
+	var texts []string
+	for _, c := range comments {
+		texts = append(texts, c.text)
+	}
+	return texts
+}
+
+func (p *jsonParser) errorf(format string, args ...any) error {
+	line := bytes.Count(p.data[:p.pos], []byte("\n")) + 1
+	col := p.pos - bytes.LastIndexByte(p.data[:p.pos], '\n')
+	return fmt.Errorf("line %d, column %d: %s", line, col, fmt.Sprintf(format, args...))
+}
+
+func (p *jsonParser) peek() byte {
+	if p.pos < len(p.data) {
+		return p.data[p.pos]
+	}
+	return 0
+}
+
+// skip consumes whitespace and comments, returning the comments.
+func (p *jsonParser) skip() []jsonComment {
+	var comments []jsonComment
+	for p.pos < len(p.data) {
+		switch c := p.data[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			p.pos++
+		case bytes.HasPrefix(p.data[p.pos:], []byte("\ufeff")):
+			p.pos += 3
+		case bytes.HasPrefix(p.data[p.pos:], []byte("//")):
+			end := bytes.IndexByte(p.data[p.pos:], '\n')
+			if end < 0 {
+				end = len(p.data) - p.pos
+			}
+			comments = append(comments, p.comment(p.pos, p.pos+end))
+		case bytes.HasPrefix(p.data[p.pos:], []byte("/*")):
+			end := bytes.Index(p.data[p.pos+2:], []byte("*/"))
+			if end < 0 {
+				return comments // reported as unexpected input by the caller
+			}
+			comments = append(comments, p.comment(p.pos, p.pos+2+end+2))
+		default:
+			return comments
+		}
+	}
+	return comments
+}
+
+func (p *jsonParser) comment(start, end int) jsonComment {
+	inline := p.lastEnd > 0 && !bytes.ContainsRune(p.data[p.lastEnd:start], '\n')
+	p.pos = end
+	return jsonComment{text: strings.TrimRight(string(p.data[start:end]), " \t\r"), inline: inline}
+}
+
+// splitComments separates the comments after an entry into its inline
+// comment and the ones that belong to whatever follows.
+func splitComments(comments []jsonComment) (inline string, rest []string) {
+	var inlines []string
+	for _, c := range comments {
+		if c.inline && len(rest) == 0 {
+			inlines = append(inlines, c.text)
+		} else {
+			rest = append(rest, c.text)
+		}
+	}
+	return strings.Join(inlines, " "), rest
+}
+
+func (p *jsonParser) value() (any, error) {
+	var v any
+	var err error
+	switch c := p.peek(); {
+	case c == '{':
+		v, err = p.object()
+	case c == '[':
+		v, err = p.array()
+	case c == '"' || c == '\'':
+		v, err = p.string()
+	case c == '-' || c == '+' || c == '.' || c >= '0' && c <= '9':
+		v, err = p.number()
+	default:
+		word := p.identifier()
+		switch word {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		case "Infinity", "NaN":
+			v = json5Number(word)
+		case "":
+			return nil, p.errorf("unexpected %q", p.rest())
+		default:
+			return nil, p.errorf("unexpected %q", word)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.lastEnd = p.pos
+	return v, nil
+}
+
+func (p *jsonParser) rest() string {
+	r, _ := utf8.DecodeRune(p.data[p.pos:])
+	if r == utf8.RuneError {
+		return "end of input"
+	}
+	return string(r)
+}
+
+// source returns the source of v, parsed from data[start:p.pos], or nil when
+// it isn't plain JSON laid out below its first line.
+func (p *jsonParser) source(start int, v any) *jsonSource {
+	text := p.data[start:p.pos]
+	if p.layout || !json.Valid(text) {
+		return nil
+	}
+	indent := []byte(lineIndent(p.data, start))
+	lines := bytes.Split(text, []byte("\n"))
+	for i, line := range lines[1:] {
+		if !bytes.HasPrefix(line, indent) {
+			return nil
+		}
+		lines[i+1] = line[len(indent):]
+	}
+	return &jsonSource{text: string(bytes.Join(lines, []byte("\n"))), sum: jsonSum(v)}
+}
+
+func (p *jsonParser) object() (*orderedMap, error) {
+	start := p.pos
+	p.pos++ // {
+	p.lastEnd = p.pos
+	obj := &orderedMap{}
+	var pending []string
+	comments := p.skip()
+	for {
+		inline, rest := splitComments(comments)
+		if inline != "" && len(obj.keys) > 0 {
+			obj.comment(obj.keys[len(obj.keys)-1]).inline = inline
+		} else if inline != "" {
+			rest = append([]string{inline}, rest...)
+		}
+		pending = append(pending, rest...)
+
+		if p.peek() == '}' {
+			p.pos++
+			obj.end = pending
+			obj.source = p.source(start, obj)
+			return obj, nil
+		}
+		key, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, commentTexts(p.skip())...)
+		if p.peek() != ':' {
+			return nil, p.errorf("expected ':' after object key")
+		}
+		p.pos++
+		pending = append(pending, commentTexts(p.skip())...)
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		obj.Set(key, value)
+		if _, ok := value.([]any); ok && p.arraySource != nil {
+			obj.comment(key).source = p.arraySource
+		}
+		if len(pending) > 0 {
+			obj.comment(key).before = pending
+			pending = nil
+		}
+
+		if comments, err = p.separator('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// separator consumes the comma after a value, if there is one, and returns
+// the comments around it.
+func (p *jsonParser) separator(close byte) ([]jsonComment, error) {
+	comments := p.skip()
+	switch p.peek() {
+	case ',':
+		p.pos++
+		p.lastEnd = p.pos
+		return append(comments, p.skip()...), nil
+	case close:
+		return comments, nil
+	}
+	return nil, p.errorf("expected ',' or '%c'", close)
+}
+
+func (m *orderedMap) comment(key string) *jsonComments {
+	if m.comments == nil {
+		m.comments = map[string]*jsonComments{}
+	}
+	c, ok := m.comments[key]
+	if !ok {
+		c = &jsonComments{}
+		m.comments[key] = c
+	}
+	return c
+}
+
+func (p *jsonParser) array() ([]any, error) {
+	start := p.pos
+	p.pos++ // [
+	p.lastEnd = p.pos
+	items := []any{}
+	var pending []string
+	comments := p.skip()
+	for {
+		inline, rest := splitComments(comments)
+		if inline != "" && len(items) > 0 {
+			last := len(items) - 1
+			cv, ok := items[last].(*commentedValue)
+			if !ok {
+				cv = &commentedValue{value: items[last]}
+				items[last] = cv
+			}
+			cv.inline = inline
+		} else if inline != "" {
+			rest = append([]string{inline}, rest...)
+		}
+		pending = append(pending, rest...)
+
+		if p.peek() == ']' {
+			p.pos++
+			if len(pending) > 0 {
+				items = append(items, jsonEndComments(pending))
+			}
+			p.arraySource = p.source(start, items)
+			return items, nil
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		var src *jsonSource
+		if _, ok := value.([]any); ok {
+			src = p.arraySource
+		}
+		if len(pending) > 0 || src != nil {
+			value = &commentedValue{jsonComments: jsonComments{before: pending, source: src}, value: value}
+			pending = nil
+		}
+		items = append(items, value)
+
+		if comments, err = p.separator(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *jsonParser) key() (string, error) {
+	if c := p.peek(); c == '"' || c == '\'' {
+		return p.string()
+	}
+	if key := p.identifier(); key != "" {
+		return key, nil
+	}
+	return "", p.errorf("expected an object key, found %q", p.rest())
+}
+
+// identifier consumes a JSON5 identifier (an unquoted key or a literal).
+func (p *jsonParser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.data) {
+		r, size := utf8.DecodeRune(p.data[p.pos:])
+		if r == '_' || r == '$' || unicode.IsLetter(r) || p.pos > start && unicode.IsDigit(r) {
+			p.pos += size
+			continue
+		}
+		break
+	}
+	return string(p.data[start:p.pos])
+}
+
+func (p *jsonParser) number() (any, error) {
+	start := p.pos
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '.' || c == '+' || c == '-' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	raw := string(p.data[start:p.pos])
+	if json.Valid([]byte(raw)) {
+		return json.Number(raw), nil
+	}
+	if isJSON5Number(raw) {
+		return json5Number(raw), nil
+	}
+	p.pos = start
+	return nil, p.errorf("invalid number %q", raw)
+}
+
+// isJSON5Number reports whether s is a JSON5 number: hexadecimal, Infinity,
+// NaN, or a decimal with a leading "+" or a leading or trailing ".".
+func isJSON5Number(s string) bool {
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	switch {
+	case s == "Infinity" || s == "NaN":
+		return true
+	case len(s) > 2 && (s[:2] == "0x" || s[:2] == "0X"):
+		_, err := strconv.ParseUint(s[2:], 16, 64)
+		return err == nil
+	case strings.ContainsAny(s, "_xXpPiInN"):
+		return false
+	}
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+// string decodes a double- or single-quoted string with JSON5 escapes.
+func (p *jsonParser) string() (string, error) {
+	quote := p.data[p.pos]
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		case c == '\n':
+			return "", p.errorf("unterminated string")
+		case c == '\\':
+			if err := p.escape(&sb); err != nil {
+				return "", err
+			}
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *jsonParser) escape(sb *strings.Builder) error {
+	p.pos++ // backslash
+	if p.pos >= len(p.data) {
+		return p.errorf("unterminated string")
+	}
+	c := p.data[p.pos]
+	p.pos++
+	switch c {
+	case 'b':
+		sb.WriteByte('\b')
+	case 'f':
+		sb.WriteByte('\f')
+	case 'n':
+		sb.WriteByte('\n')
+	case 'r':
+		sb.WriteByte('\r')
+	case 't':
+		sb.WriteByte('\t')
+	case 'v':
+		sb.WriteByte('\v')
+	case '0':
+		sb.WriteByte(0)
+	case '\n':
+		// line continuation
+	case '\r':
+		if p.peek() == '\n' {
+			p.pos++
+		}
+	case 'x', 'u':
+		n := 2
+		if c == 'u' {
+			n = 4
+		}
+		if p.pos+n > len(p.data) {
+			return p.errorf("invalid escape")
+		}
+		code, err := strconv.ParseUint(string(p.data[p.pos:p.pos+n]), 16, 32)
+		if err != nil {
+			return p.errorf("invalid escape")
+		}
+		p.pos += n
+		r := rune(code)
+		if utf16.IsSurrogate(r) && bytes.HasPrefix(p.data[p.pos:], []byte(`\u`)) && p.pos+6 <= len(p.data) {
+			if low, err := strconv.ParseUint(string(p.data[p.pos+2:p.pos+6]), 16, 32); err == nil {
+				r = utf16.DecodeRune(r, rune(low))
+				p.pos += 6
+			}
+		}
+		sb.WriteRune(r)
+	default:
+		sb.WriteByte(c)
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// orderedMap is a JSON object that keeps its keys in insertion order. Merges
+// parse existing files into orderedMaps so re-encoding keeps the file's key
+// order and comments.
+type orderedMap struct {
+	keys     []string
+	values   map[string]any
+	comments map[string]*jsonComments
+	end      []string // comments before the closing brace
+	source   *jsonSource
+	// comments before and after the top-level object
+	head, tail []string
+}
+
+// jsonComments are the comments around an object entry or array element,
+// kept verbatim ("// ..." or "/* ... */").
+type jsonComments struct {
+	before []string    // on their own lines above the entry
+	inline string      // on the same line, after the value
+	source *jsonSource // of an array value; objects keep their own
+}
+
+// jsonSource is the text a parsed object or array was read from, so a value
+// the merge didn't change is written back as it was. sum is the value as
+// jsonSum wrote it when parsed.
+type jsonSource struct {
+	text string // continuation lines without the first line's indent
+	sum  string
+}
+
+// commentedValue is an array element with comments. Code that inspects array
+// elements unwraps them with jsonElem.
+type commentedValue struct {
+	jsonComments
+	value any
+}
+
+// jsonEndComments holds the comments before an array's closing bracket. It
+// is always the last element.
+type jsonEndComments []string
+
+// json5Number is a JSON5 number JSON can't represent, such as 0x1F or
+// Infinity. It is written back unchanged.
+type json5Number string
+
+// jsonElem returns v without its comments.
+func jsonElem(v any) any {
+	if c, ok := v.(*commentedValue); ok {
+		return c.value
+	}
+	return v
+}
+
+// Set adds or replaces key, keeping its original position (and comments)
+// when replacing.
+func (m *orderedMap) Set(key string, value any) {
+	if m.values == nil {
+		m.values = map[string]any{}
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Delete removes key and its comments, if present.
+func (m *orderedMap) Delete(key string) {
+	if _, ok := m.values[key]; !ok {
+		return
+	}
+	delete(m.values, key)
+	delete(m.comments, key)
+	m.keys = slices.DeleteFunc(m.keys, func(k string) bool { return k == key })
+}
+
+// SetAll sets every key of values, adding new keys in sorted order.
+func (m *orderedMap) SetAll(values map[string]any) {
+	for _, k := range slices.Sorted(maps.Keys(values)) {
+		m.Set(k, values[k])
+	}
+}
+
+// Object returns the object stored under key, creating it (or replacing a
+// non-object value) when needed.
+func (m *orderedMap) Object(key string) *orderedMap {
+	if child, ok := m.values[key].(*orderedMap); ok {
+		return child
+	}
+	child := &orderedMap{}
+	m.Set(key, child)
+	return child
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := encodeJSONValue(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := encodeJSONValue(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonIndent returns the indent unit of data: the leading whitespace of its
+// first indented line, or two spaces when it has none.
+func jsonIndent(data []byte) string {
+	for _, line := range bytes.Split(data, []byte("\n"))[1:] {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if len(trimmed) > 0 && len(trimmed) < len(line) {
+			return string(line[:len(line)-len(trimmed)])
+		}
+	}
+	return "  "
+}
+
+// encodeJSONValue encodes v compactly, without escaping <, > and &.
+func encodeJSONValue(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func marshalJSON(v any) ([]byte, error) {
+	return marshalJSONIndent(v, "  ")
+}
+
+// marshalJSONIndent is marshalJSON with the given indent unit, for merges that
+// keep the existing file's indentation. Parsed objects and arrays are written
+// with their comments; everything else is laid out like json.MarshalIndent.
+func marshalJSONIndent(v any, indent string) ([]byte, error) {
+	w := &jsonWriter{indent: indent}
+	if m, ok := v.(*orderedMap); ok {
+		w.comments(m.head, 0)
+	}
+	if err := w.value(v, 0, nil); err != nil {
+		return nil, err
+	}
+	w.buf.WriteByte('\n')
+	if m, ok := v.(*orderedMap); ok {
+		w.comments(m.tail, 0)
+	}
+	return w.buf.Bytes(), nil
+}
+
+type jsonWriter struct {
+	buf    bytes.Buffer
+	indent string
+	layout bool // ignore sources and lay out every value
+}
+
+// jsonSum returns v written without its sources, for telling whether a
+// parsed value has changed since.
+func jsonSum(v any) string {
+	w := &jsonWriter{indent: " ", layout: true}
+	if err := w.value(v, 0, nil); err != nil {
+		return ""
+	}
+	return w.buf.String()
+}
+
+// comments writes each comment on its own line at depth, followed by a
+// newline.
+func (w *jsonWriter) comments(comments []string, depth int) {
+	for _, c := range comments {
+		w.buf.WriteString(strings.Repeat(w.indent, depth))
+		w.buf.WriteString(c)
+		w.buf.WriteByte('\n')
+	}
+}
+
+// value writes v at depth. An unchanged parsed value is written from its
+// source: an object's own, or src for an array.
+func (w *jsonWriter) value(v any, depth int, src *jsonSource) error {
+	if m, ok := v.(*orderedMap); ok {
+		src = m.source
+	}
+	if !w.layout && src != nil && src.sum == jsonSum(v) {
+		w.buf.WriteString(reindent([]byte(src.text), strings.Repeat(w.indent, depth)))
+		return nil
+	}
+
+	switch v := v.(type) {
+	case *orderedMap:
+		return w.object(v, depth)
+	case []any:
+		return w.array(v, depth)
+	case json5Number:
+		w.buf.WriteString(string(v))
+		return nil
+	}
+
+	data, err := encodeJSONValue(v)
+	if err != nil {
+		return err
+	}
+	return json.Indent(&w.buf, data, strings.Repeat(w.indent, depth), w.indent)
+}
+
+func (w *jsonWriter) object(m *orderedMap, depth int) error {
+	if len(m.keys) == 0 && len(m.end) == 0 {
+		w.buf.WriteString("{}")
+		return nil
+	}
+	w.buf.WriteString("{")
+	for i, key := range m.keys {
+		c := m.comments[key]
+		if c == nil {
+			c = &jsonComments{}
+		}
+		w.buf.WriteByte('\n')
+		w.comments(c.before, depth+1)
+		w.buf.WriteString(strings.Repeat(w.indent, depth+1))
+		k, err := encodeJSONValue(key)
+		if err != nil {
+			return err
+		}
+		w.buf.Write(k)
+		w.buf.WriteString(": ")
+		if err := w.value(m.values[key], depth+1, c.source); err != nil {
+			return err
+		}
+		if i < len(m.keys)-1 {
+			w.buf.WriteByte(',')
+		}
+		if c.inline != "" {
+			w.buf.WriteString(" " + c.inline)
+		}
+	}
+	w.buf.WriteByte('\n')
+	w.comments(m.end, depth+1)
+	w.buf.WriteString(strings.Repeat(w.indent, depth))
+	w.buf.WriteString("}")
+	return nil
+}
+
+func (w *jsonWriter) array(items []any, depth int) error {
+	var end jsonEndComments
+	if n := len(items); n > 0 {
+		if e, ok := items[n-1].(jsonEndComments); ok {
+			end, items = e, items[:n-1]
+		}
+	}
+	if len(items) == 0 && len(end) == 0 {
+		w.buf.WriteString("[]")
+		return nil
+	}
+	w.buf.WriteString("[")
+	for i, item := range items {
+		c := &jsonComments{}
+		if cv, ok := item.(*commentedValue); ok {
+			c, item = &cv.jsonComments, cv.value
+		}
+		w.buf.WriteByte('\n')
+		w.comments(c.before, depth+1)
+		w.buf.WriteString(strings.Repeat(w.indent, depth+1))
+		if err := w.value(item, depth+1, c.source); err != nil {
+			return err
+		}
+		if i < len(items)-1 {
+			w.buf.WriteByte(',')
+		}
+		if c.inline != "" {
+			w.buf.WriteString(" " + c.inline)
+		}
+	}
+	w.buf.WriteByte('\n')
+	w.comments(end, depth+1)
+	w.buf.WriteString(strings.Repeat(w.indent, depth))
+	w.buf.WriteString("]")
+	return nil
+}
@@ -0,0 +1,383 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+	"github.com/tylerbutler/commit-config-gen/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Location is one of the places a tool reads its config from.
+type Location struct {
+	Path   string // relative to the output directory
+	Format string // formatJSON, formatYAML or formatTOML; "" for JSON or YAML
+	// Section is the key path of the config inside a file shared with other
+	// tools, such as {"release"} in package.json or {"tool", "git-cliff"} in
+	// pyproject.toml.
+	Section []string
+	// Key, for a shared TOML file laid out like the generated document (such
+	// as pyproject.toml), is the table the generator owns. It must be present
+	// for the file to count, and only its tables are rewritten.
+	Key []string
+}
+
+// Locations is implemented by generators whose tool reads its config from
+// more than one place, in the order the tool looks. FileName is the first
+// location without a Section or Key; it is created when none exist.
+type Locations interface {
+	Locations() []Location
+}
+
+// Locator is implemented by generators whose file has no fixed name. Locate
+// returns the path of the file to update, relative to dir, or "" to use
+// FileName.
+type Locator interface {
+	Locate(dir string) (string, error)
+}
+
+// Target is the file a generator updates, as chosen by Resolve.
+type Target struct {
+	Location
+	Path string // Location.Path under the output directory
+}
+
+// locations returns g's candidate locations.
+func locations(g Generator) []Location {
+	if l, ok := g.(Locations); ok {
+		return l.Locations()
+	}
+	return []Location{{Path: g.FileName(), Format: formatOf(g.FileName())}}
+}
+
+// Resolve returns the file g should update under dir: the first of its
+// locations that already holds the tool's config, or else its FileName. A
+// shared file only counts when it has the tool's Section or Key. Only
+// one location is ever used, so a second, conflicting config isn't created.
+func Resolve(g Generator, dir string) (Target, error) {
+	if l, ok := g.(Locator); ok {
+		rel, err := l.Locate(dir)
+		if err != nil {
+			return Target{}, err
+		}
+		if rel != "" {
+			return Target{Location: Location{Path: rel, Format: formatOf(rel)}, Path: filepath.Join(dir, rel)}, nil
+		}
+	}
+
+	candidates := locations(g)
+	for _, loc := range candidates {
+		path := filepath.Join(dir, loc.Path)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return Target{}, err
+		}
+		if loc.Format == "" {
+			loc.Format = formatYAML
+			if _, err := parseJSONObject(data); err == nil {
+				loc.Format = formatJSON
+			}
+		}
+		required := loc.Key
+		if loc.Section != nil {
+			required = loc.Section
+		}
+		if required != nil {
+			if ok, err := hasKey(data, loc.Format, required); err != nil {
+				return Target{}, fmt.Errorf("reading %s: %w", path, err)
+			} else if !ok {
+				continue
+			}
+		}
+		return Target{Location: loc, Path: path}, nil
+	}
+	def := defaultLocation(candidates)
+	return Target{Location: def, Path: filepath.Join(dir, def.Path)}, nil
+}
+
+// defaultLocation returns the location to create when none exist: the first
+// one that is a file of its own, rather than part of a shared file.
+func defaultLocation(candidates []Location) Location {
+	for _, loc := range candidates {
+		if loc.Section == nil && loc.Key == nil {
+			return loc
+		}
+	}
+	return candidates[0]
+}
+
+// Generate runs g against existing, the current content of the target file
+// (nil when it doesn't exist). When the file's format differs from g's own,
+// the content is converted for g and its output converted back. When g's
+// config is embedded in a shared file, only that part is handed to g and
+// rewritten.
+func (t Target) Generate(g Generator, cfg *config.Config, existing []byte) ([]byte, error) {
+	native := formatOf(g.FileName())
+	embedded := t.Section != nil || t.Key != nil
+	if existing == nil || (!embedded && t.Format == native) {
+		return g.Generate(cfg, existing)
+	}
+
+	if embedded {
+		if t.Format != native {
+			return nil, fmt.Errorf("can't embed %s config in %s", native, t.Path)
+		}
+		e, err := newEmbedding(existing, t.Location)
+		if err != nil {
+			return nil, fmt.Errorf("parsing existing %s: %w", t.Path, err)
+		}
+		doc, err := e.doc()
+		if err != nil {
+			return nil, fmt.Errorf("parsing existing %s: %w", t.Path, err)
+		}
+		out, err := g.Generate(cfg, doc)
+		if err != nil {
+			return nil, err
+		}
+		return e.replace(out)
+	}
+
+	in, err := convertFormat(existing, t.Format, native)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s: %w", t.Path, err)
+	}
+	out, err := g.Generate(cfg, in)
+	if err != nil {
+		return nil, err
+	}
+	return convertBack(out, native, t.Format, existing)
+}
+
+func formatOf(path string) string {
+	switch filepath.Ext(path) {
+	case ".json", ".json5":
+		return formatJSON
+	case ".yaml", ".yml":
+		return formatYAML
+	case ".toml":
+		return formatTOML
+	}
+	return ""
+}
+
+// hasKey reports whether the document data, in format f, has a value at keys.
+func hasKey(data []byte, f string, keys []string) (bool, error) {
+	var doc any
+	switch f {
+	case formatJSON:
+		obj, err := parseJSONObject(data)
+		if err != nil {
+			return false, err
+		}
+		_, ok := lookupJSON(obj, keys)
+		return ok, nil
+	case formatYAML:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return false, err
+		}
+	case formatTOML:
+		if err := toml.Unmarshal(data, &doc); err != nil {
+			return false, err
+		}
+	default:
+		return false, fmt.Errorf("unsupported format %q", f)
+	}
+	for _, key := range keys {
+		m, ok := doc.(map[string]any)
+		if !ok {
+			return false, nil
+		}
+		if doc, ok = m[key]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// lookupJSON returns the object at keys in obj.
+func lookupJSON(obj *orderedMap, keys []string) (*orderedMap, bool) {
+	for _, key := range keys {
+		child, ok := obj.values[key].(*orderedMap)
+		if !ok {
+			return nil, false
+		}
+		obj = child
+	}
+	return obj, true
+}
+
+// convertFormat converts a document from one format to another. Only JSON
+// and YAML documents can be converted.
+func convertFormat(data []byte, from, to string) ([]byte, error) {
+	switch {
+	case from == to:
+		return data, nil
+	case from == formatYAML && to == formatJSON:
+		var root yaml.Node
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return nil, err
+		}
+		value, err := yamlToJSON(&root)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := value.(*orderedMap); !ok {
+			return nil, fmt.Errorf("expected a mapping at the top level")
+		}
+		return marshalJSON(value)
+	}
+	return nil, fmt.Errorf("can't convert %s to %s", from, to)
+}
+
+// convertBack converts a generator's output to the target file's format.
+// YAML output is synced into the existing document, so comments on values
+// that didn't change are kept.
+func convertBack(out []byte, from, to string, existing []byte) ([]byte, error) {
+	if from != formatJSON || to != formatYAML {
+		return nil, fmt.Errorf("can't convert %s to %s", from, to)
+	}
+	obj, err := parseJSONObject(out)
+	if err != nil {
+		return nil, err
+	}
+	generated, err := jsonToYAML(obj)
+	if err != nil {
+		return nil, err
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(existing, &root); err != nil {
+		return nil, err
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root.Content[0] = syncYAMLNode(root.Content[0], generated)
+	} else {
+		root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{generated}}
+	}
+	return encodeYAML(&root)
+}
+
+// yamlToJSON converts a YAML node to the values parseJSONObject returns.
+func yamlToJSON(n *yaml.Node) (any, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return &orderedMap{}, nil
+		}
+		return yamlToJSON(n.Content[0])
+	case yaml.AliasNode:
+		return yamlToJSON(n.Alias)
+	case yaml.MappingNode:
+		obj := &orderedMap{}
+		for i := 0; i < len(n.Content)-1; i += 2 {
+			value, err := yamlToJSON(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			obj.Set(n.Content[i].Value, value)
+		}
+		return obj, nil
+	case yaml.SequenceNode:
+		items := []any{}
+		for _, item := range n.Content {
+			value, err := yamlToJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	}
+	var value any
+	if err := n.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// jsonToYAML converts parsed JSON to a YAML node.
+func jsonToYAML(v any) (*yaml.Node, error) {
+	switch v := jsonElem(v).(type) {
+	case *orderedMap:
+		n := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, key := range v.keys {
+			value, err := jsonToYAML(v.values[key])
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+		}
+		return n, nil
+	case []any:
+		n := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range v {
+			if _, ok := item.(jsonEndComments); ok {
+				continue
+			}
+			value, err := jsonToYAML(item)
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, value)
+		}
+		return n, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(string(v), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: string(v)}, nil
+	case json5Number:
+		value := strings.NewReplacer("Infinity", ".inf", "NaN", ".nan").Replace(string(v))
+		var decoded any
+		if err := yaml.Unmarshal([]byte(value), &decoded); err != nil {
+			return nil, err
+		}
+		n := &yaml.Node{}
+		if err := n.Encode(decoded); err != nil {
+			return nil, err
+		}
+		return n, nil
+	default:
+		n := &yaml.Node{}
+		if err := n.Encode(v); err != nil {
+			return nil, err
+		}
+		return n, nil
+	}
+}
+
+// syncYAMLNode returns generated, reusing the nodes of existing wherever they
+// hold the same value, so their comments and styles survive.
+func syncYAMLNode(existing, generated *yaml.Node) *yaml.Node {
+	switch {
+	case existing.Kind == yaml.MappingNode && generated.Kind == yaml.MappingNode:
+		var content []*yaml.Node
+		for i := 0; i < len(generated.Content)-1; i += 2 {
+			key, value := generated.Content[i], generated.Content[i+1]
+			for j := 0; j < len(existing.Content)-1; j += 2 {
+				if existing.Content[j].Value == key.Value {
+					key, value = existing.Content[j], syncYAMLNode(existing.Content[j+1], value)
+					break
+				}
+			}
+			content = append(content, key, value)
+		}
+		existing.Content = content
+		return existing
+	case existing.Kind == yaml.SequenceNode && generated.Kind == yaml.SequenceNode && len(existing.Content) == len(generated.Content):
+		for i := range existing.Content {
+			existing.Content[i] = syncYAMLNode(existing.Content[i], generated.Content[i])
+		}
+		return existing
+	case existing.Kind == yaml.ScalarNode && generated.Kind == yaml.ScalarNode &&
+		existing.Value == generated.Value && existing.ShortTag() == generated.ShortTag():
+		return existing
+	}
+	return generated
+}
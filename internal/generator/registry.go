@@ -17,6 +17,15 @@ type Generator interface {
 	Generate(cfg *config.Config, existing []byte) ([]byte, error)
 }
 
+// SkipError is returned by Generate when the config gives the generator
+// nothing to write, such as no commit type for dependency updates. The file is
+// left as it is.
+type SkipError struct {
+	Reason string
+}
+
+func (e *SkipError) Error() string { return e.Reason }
+
 var (
 	mu       sync.Mutex
 	registry = map[string]Generator{}
@@ -0,0 +1,202 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/tylerbutler/commit-config-gen/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	Register(&ReleaseDrafterGenerator{})
+}
+
+// ReleaseDrafterGenerator generates .github/release-drafter.yml. Like
+// github-release-notes, it labels pull requests with their type name.
+type ReleaseDrafterGenerator struct{}
+
+func (g *ReleaseDrafterGenerator) Name() string     { return "release-drafter" }
+func (g *ReleaseDrafterGenerator) FileName() string { return ".github/release-drafter.yml" }
+
+// Locations returns both extensions release-drafter reads.
+func (g *ReleaseDrafterGenerator) Locations() []Location {
+	return []Location{
+		{Path: ".github/release-drafter.yml", Format: formatYAML},
+		{Path: ".github/release-drafter.yaml", Format: formatYAML},
+	}
+}
+
+func (g *ReleaseDrafterGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
+	drafter := releaseDrafterConfig{
+		Categories:     buildReleaseCategories(cfg),
+		ExcludeLabels:  hiddenTypeNames(cfg),
+		VersionLabels:  buildVersionResolverLabels(cfg),
+		Autolabeler:    buildAutolabeler(cfg),
+		DefaultVersion: "patch",
+	}
+
+	var out []byte
+	var err error
+	if existing != nil {
+		out, err = mergeReleaseDrafter(existing, drafter)
+	} else {
+		out, err = freshReleaseDrafter(drafter)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatYAML, cfg.GeneratorExtra(g.Name()))
+}
+
+type releaseDrafterConfig struct {
+	Categories     []releaseCategory
+	ExcludeLabels  []string
+	VersionLabels  map[string][]string // bump level -> labels
+	Autolabeler    []autolabelRule
+	DefaultVersion string
+}
+
+type autolabelRule struct {
+	Label string   `yaml:"label"`
+	Title []string `yaml:"title,omitempty"`
+	Body  []string `yaml:"body,omitempty"`
+}
+
+type labelList struct {
+	Labels []string `yaml:"labels"`
+}
+
+// versionLevels are the version-resolver keys, in output order.
+var versionLevels = []string{"major", "minor", "patch"}
+
+func buildVersionResolverLabels(cfg *config.Config) map[string][]string {
+	labels := map[string][]string{}
+	if cfg.Breaking != nil && cfg.Breaking.ChangelogGroup != nil {
+		level := cfg.Breaking.BumpLevel()
+		labels[level] = append(labels[level], breakingLabel)
+	}
+	for _, name := range cfg.TypeNames() {
+		if bump := cfg.Types[name].Bump; slices.Contains(versionLevels, bump) {
+			labels[bump] = append(labels[bump], name)
+		}
+	}
+	return labels
+}
+
+// buildAutolabeler labels pull requests from their conventional-commit title.
+// Aliases add extra patterns for their type's label.
+func buildAutolabeler(cfg *config.Config) []autolabelRule {
+	var rules []autolabelRule
+	if cfg.Breaking != nil && cfg.Breaking.ChangelogGroup != nil {
+		message, footer := breakingPatterns(cfg)
+		rule := autolabelRule{Label: breakingLabel, Body: []string{"/" + footer + "/m"}}
+		if cfg.Breaking.Types == nil || len(cfg.Breaking.Types) > 0 {
+			rule.Title = []string{"/" + message + "/"}
+		}
+		rules = append(rules, rule)
+	}
+	for _, name := range cfg.TypeNames() {
+		rule := autolabelRule{Label: name}
+		for _, n := range typeAndAliases(cfg, name) {
+			rule.Title = append(rule.Title, "/^"+n+`(\(.*\))?!?:/`)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func (d releaseDrafterConfig) versionResolver() map[string]any {
+	resolver := map[string]any{}
+	for _, level := range versionLevels {
+		if labels := d.VersionLabels[level]; len(labels) > 0 {
+			resolver[level] = labelList{Labels: labels}
+		}
+	}
+	return resolver
+}
+
+func freshReleaseDrafter(d releaseDrafterConfig) ([]byte, error) {
+	resolver := d.versionResolver()
+	resolver["default"] = d.DefaultVersion
+	doc := struct {
+		Categories      []releaseCategory `yaml:"categories"`
+		ExcludeLabels   []string          `yaml:"exclude-labels,omitempty"`
+		VersionResolver map[string]any    `yaml:"version-resolver"`
+		Autolabeler     []autolabelRule   `yaml:"autolabeler"`
+		Template        string            `yaml:"template"`
+	}{
+		Categories:      d.Categories,
+		ExcludeLabels:   d.ExcludeLabels,
+		VersionResolver: resolver,
+		Autolabeler:     d.Autolabeler,
+		Template:        "$CHANGES\n",
+	}
+	return encodeYAML(doc)
+}
+
+// mergeReleaseDrafter replaces categories, exclude-labels and the
+// version-resolver label lists. Autolabeler rules for other labels and the
+// version-resolver default are kept.
+func mergeReleaseDrafter(existing []byte, d releaseDrafterConfig) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(existing, &root); err != nil {
+		return nil, fmt.Errorf("parsing existing release-drafter.yml: %w", err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return freshReleaseDrafter(d)
+	}
+	mapping := root.Content[0]
+
+	if err := setYAMLKey(mapping, "categories", d.Categories); err != nil {
+		return nil, err
+	}
+	if len(d.ExcludeLabels) > 0 {
+		if err := setYAMLKey(mapping, "exclude-labels", d.ExcludeLabels); err != nil {
+			return nil, err
+		}
+	} else {
+		deleteYAMLKey(mapping, "exclude-labels")
+	}
+
+	resolver := ensureYAMLMapping(mapping, "version-resolver")
+	for _, level := range versionLevels {
+		labels := d.VersionLabels[level]
+		if len(labels) == 0 {
+			deleteYAMLKey(resolver, level)
+			continue
+		}
+		if err := setYAMLKey(resolver, level, labelList{Labels: labels}); err != nil {
+			return nil, err
+		}
+	}
+	if findYAMLKey(resolver, "default") == nil {
+		if err := setYAMLKey(resolver, "default", d.DefaultVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	generated := map[string]bool{}
+	rules := make([]any, 0, len(d.Autolabeler))
+	for _, rule := range d.Autolabeler {
+		generated[rule.Label] = true
+		rules = append(rules, rule)
+	}
+	if existingRules := findYAMLKey(mapping, "autolabeler"); existingRules != nil && existingRules.Kind == yaml.SequenceNode {
+		for _, rule := range existingRules.Content {
+			if label := findYAMLKey(rule, "label"); rule.Kind == yaml.MappingNode && (label == nil || !generated[label.Value]) {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	if err := setYAMLKey(mapping, "autolabeler", rules); err != nil {
+		return nil, err
+	}
+
+	data, err := encodeYAML(&root)
+	if err != nil {
+		return nil, fmt.Errorf("encoding release-drafter.yml: %w", err)
+	}
+	return data, nil
+}
@@ -1,8 +1,9 @@
 package generator
 
 import (
-	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/tylerbutler/commit-config-gen/internal/config"
 )
@@ -18,12 +19,27 @@ func (g *ReleasePleaseGenerator) Name() string     { return "release-please" }
 func (g *ReleasePleaseGenerator) FileName() string { return "release-please-config.json" }
 
 func (g *ReleasePleaseGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
-	sections := buildChangelogSections(cfg)
+	cfg = cfg.ForGenerator(g.Name())
+	paths := cfg.PackagePaths()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	sections := map[string][]changelogSection{}
+	for _, path := range paths {
+		sections[path] = buildChangelogSections(cfg.ForPackage(path))
+	}
 
+	var out []byte
+	var err error
 	if existing != nil {
-		return mergeReleasePlease(existing, sections)
+		out, err = mergeReleasePlease(existing, sections, buildChangelogSections(cfg))
+	} else {
+		out, err = freshReleasePlease(sections)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return freshReleasePlease(sections)
+	return applyExtras(out, formatJSON, cfg.GeneratorExtra(g.Name()))
 }
 
 type changelogSection struct {
@@ -47,35 +63,37 @@ func buildChangelogSections(cfg *config.Config) []changelogSection {
 	return sections
 }
 
-func freshReleasePlease(sections []changelogSection) ([]byte, error) {
+func freshReleasePlease(sections map[string][]changelogSection) ([]byte, error) {
+	packages := map[string]any{}
+	for path, pkgSections := range sections {
+		packages[path] = map[string]any{
+			"changelog-sections": pkgSections,
+		}
+	}
 	doc := map[string]any{
-		"packages": map[string]any{
-			".": map[string]any{
-				"changelog-sections": sections,
-			},
-		},
+		"packages": packages,
 	}
 	return marshalJSON(doc)
 }
 
-func mergeReleasePlease(existing []byte, sections []changelogSection) ([]byte, error) {
-	var doc map[string]any
-	if err := json.Unmarshal(existing, &doc); err != nil {
+// mergeReleasePlease sets changelog-sections for each package. A root "."
+// package that isn't configured in commit-types.json keeps getting the
+// top-level sections, so it doesn't go stale once packages are added.
+func mergeReleasePlease(existing []byte, sections map[string][]changelogSection, root []changelogSection) ([]byte, error) {
+	doc, err := parseJSONObject(existing)
+	if err != nil {
 		return nil, fmt.Errorf("parsing existing release-please-config.json: %w", err)
 	}
 
-	packages, ok := doc["packages"].(map[string]any)
-	if !ok {
-		packages = map[string]any{}
-		doc["packages"] = packages
+	packages := doc.Object("packages")
+	if _, ok := packages.values["."].(*orderedMap); ok {
+		if _, ok := sections["."]; !ok {
+			sections = maps.Clone(sections)
+			sections["."] = root
+		}
 	}
-
-	root, ok := packages["."].(map[string]any)
-	if !ok {
-		root = map[string]any{}
-		packages["."] = root
+	for _, path := range slices.Sorted(maps.Keys(sections)) {
+		packages.Object(path).Set("changelog-sections", sections[path])
 	}
-
-	root["changelog-sections"] = sections
-	return marshalJSON(doc)
+	return marshalJSONIndent(doc, jsonIndent(existing))
 }
@@ -1,10 +1,9 @@
 package generator
 
 import (
-	"bytes"
 	"fmt"
+	"path"
 
-	toml "github.com/pelletier/go-toml/v2"
 	"github.com/tylerbutler/commit-config-gen/internal/config"
 )
 
@@ -18,26 +17,47 @@ type ReleasePlzGenerator struct{}
 func (g *ReleasePlzGenerator) Name() string     { return "release-plz" }
 func (g *ReleasePlzGenerator) FileName() string { return "release-plz.toml" }
 
+// Locations returns both names release-plz reads.
+func (g *ReleasePlzGenerator) Locations() []Location {
+	return []Location{
+		{Path: "release-plz.toml", Format: formatTOML},
+		{Path: ".release-plz.toml", Format: formatTOML},
+	}
+}
+
 func (g *ReleasePlzGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
 	parsers := buildReleasePlzParsers(cfg)
+	protect := cfg.Breaking != nil
+	packages := buildReleasePlzPackages(cfg)
 
+	var out []byte
+	var err error
 	if existing != nil {
-		return mergeReleasePlz(existing, parsers)
+		out, err = mergeReleasePlz(existing, parsers, packages, protect)
+	} else {
+		out, err = freshReleasePlz(parsers, packages, protect)
 	}
-	return freshReleasePlz(parsers)
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatTOML, cfg.GeneratorExtra(g.Name()))
 }
 
-type releasePlzParser struct {
-	Message string `toml:"message"`
-	Group   string `toml:"group,omitempty"`
-	Skip    bool   `toml:"skip,omitempty"`
-}
+func buildReleasePlzParsers(cfg *config.Config) []commitParser {
+	var parsers []commitParser
 
-func buildReleasePlzParsers(cfg *config.Config) []releasePlzParser {
-	var parsers []releasePlzParser
+	if cfg.Breaking != nil && cfg.Breaking.ChangelogGroup != nil {
+		message, footer := breakingPatterns(cfg)
+		group := *cfg.Breaking.ChangelogGroup
+		if cfg.Breaking.Types == nil || len(cfg.Breaking.Types) > 0 {
+			parsers = append(parsers, commitParser{Message: message, Group: group})
+		}
+		parsers = append(parsers, commitParser{Footer: footer, Group: group})
+	}
 
-	for _, scope := range cfg.ExcludedScopes {
-		parsers = append(parsers, releasePlzParser{
+	for _, scope := range cfg.HiddenScopes() {
+		parsers = append(parsers, commitParser{
 			Message: fmt.Sprintf(`^[a-z]+\(%s\)`, scope),
 			Skip:    true,
 		})
@@ -46,14 +66,20 @@ func buildReleasePlzParsers(cfg *config.Config) []releasePlzParser {
 	for _, name := range cfg.TypeNames() {
 		t := cfg.Types[name]
 		if t.ChangelogGroup != nil {
-			parsers = append(parsers, releasePlzParser{
+			parsers = append(parsers, commitParser{
 				Message: "^" + name,
 				Group:   *t.ChangelogGroup,
 			})
+			for _, alias := range t.Aliases {
+				parsers = append(parsers, commitParser{
+					Message: "^" + alias.Name,
+					Group:   *t.ChangelogGroup,
+				})
+			}
 		}
 	}
 
-	parsers = append(parsers, releasePlzParser{
+	parsers = append(parsers, commitParser{
 		Message: ".*",
 		Skip:    true,
 	})
@@ -61,56 +87,52 @@ func buildReleasePlzParsers(cfg *config.Config) []releasePlzParser {
 	return parsers
 }
 
-func freshReleasePlz(parsers []releasePlzParser) ([]byte, error) {
-	doc := map[string]any{
-		"changelog": map[string]any{
-			"commit_parsers": tomlParsers(parsers),
-		},
-	}
+type releasePlzPackage struct {
+	Name          string
+	ChangelogPath string
+}
 
-	var buf bytes.Buffer
-	enc := toml.NewEncoder(&buf)
-	enc.SetIndentTables(true)
-	if err := enc.Encode(doc); err != nil {
-		return nil, err
+// buildReleasePlzPackages returns one [[package]] entry per configured package.
+// release-plz reads commit_parsers only from the workspace [changelog] table,
+// so per-package types and excluded scopes can't be applied.
+func buildReleasePlzPackages(cfg *config.Config) []releasePlzPackage {
+	var packages []releasePlzPackage
+	for _, p := range cfg.PackagePaths() {
+		packages = append(packages, releasePlzPackage{
+			Name:          cfg.PackageName(p),
+			ChangelogPath: path.Join(p, "CHANGELOG.md"),
+		})
 	}
-	return buf.Bytes(), nil
+	return packages
+}
+
+// freshReleasePlz writes the generated settings into an empty file, so fresh
+// output has the same layout as a merge.
+func freshReleasePlz(parsers []commitParser, packages []releasePlzPackage, protect bool) ([]byte, error) {
+	return mergeReleasePlz([]byte{}, parsers, packages, protect)
 }
 
-func mergeReleasePlz(existing []byte, parsers []releasePlzParser) ([]byte, error) {
-	var doc map[string]any
-	if err := toml.Unmarshal(existing, &doc); err != nil {
+// mergeReleasePlz replaces changelog.commit_parsers in place and sets
+// changelog_path on each package's [[package]] table. The rest of the file,
+// comments included, is left as it was.
+func mergeReleasePlz(existing []byte, parsers []commitParser, packages []releasePlzPackage, protect bool) ([]byte, error) {
+	out, err := deleteTOMLArrayTables(existing, []string{"changelog", "commit_parsers"})
+	if err != nil {
 		return nil, fmt.Errorf("parsing existing release-plz.toml: %w", err)
 	}
-
-	changelog, ok := doc["changelog"].(map[string]any)
-	if !ok {
-		changelog = map[string]any{}
-		doc["changelog"] = changelog
+	if protect {
+		if out, err = setTOMLValue(out, []string{"changelog"}, "protect_breaking_commits", "true"); err != nil {
+			return nil, fmt.Errorf("parsing existing release-plz.toml: %w", err)
+		}
 	}
-
-	changelog["commit_parsers"] = tomlParsers(parsers)
-
-	var buf bytes.Buffer
-	enc := toml.NewEncoder(&buf)
-	enc.SetIndentTables(true)
-	if err := enc.Encode(doc); err != nil {
-		return nil, fmt.Errorf("encoding release-plz.toml: %w", err)
+	if out, err = setTOMLValue(out, []string{"changelog"}, "commit_parsers", tomlParserArray(parsers)); err != nil {
+		return nil, fmt.Errorf("parsing existing release-plz.toml: %w", err)
 	}
-	return buf.Bytes(), nil
-}
-
-func tomlParsers(parsers []releasePlzParser) []any {
-	var result []any
-	for _, p := range parsers {
-		m := map[string]any{"message": p.Message}
-		if p.Group != "" {
-			m["group"] = p.Group
-		}
-		if p.Skip {
-			m["skip"] = p.Skip
+	for _, p := range packages {
+		out, err = setTOMLArrayTableValue(out, []string{"package"}, "name", p.Name, "changelog_path", tomlString(p.ChangelogPath))
+		if err != nil {
+			return nil, fmt.Errorf("parsing existing release-plz.toml: %w", err)
 		}
-		result = append(result, m)
 	}
-	return result
+	return out, nil
 }
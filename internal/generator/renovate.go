@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/tylerbutler/commit-config-gen/internal/config"
+)
+
+func init() {
+	Register(&RenovateGenerator{})
+}
+
+// RenovateGenerator generates the semantic commit settings of renovate.json.
+type RenovateGenerator struct{}
+
+func (g *RenovateGenerator) Name() string     { return "renovate" }
+func (g *RenovateGenerator) FileName() string { return "renovate.json" }
+
+// Locations returns Renovate's config file names, in the order it checks them.
+func (g *RenovateGenerator) Locations() []Location {
+	return []Location{
+		{Path: "renovate.json", Format: formatJSON},
+		{Path: "renovate.json5", Format: formatJSON},
+		{Path: ".github/renovate.json", Format: formatJSON},
+		{Path: ".github/renovate.json5", Format: formatJSON},
+		{Path: ".gitlab/renovate.json", Format: formatJSON},
+		{Path: ".gitlab/renovate.json5", Format: formatJSON},
+		{Path: ".renovaterc", Format: formatJSON},
+		{Path: ".renovaterc.json", Format: formatJSON},
+		{Path: ".renovaterc.json5", Format: formatJSON},
+		{Path: "package.json", Format: formatJSON, Section: []string{"renovate"}},
+	}
+}
+
+func (g *RenovateGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
+	settings, err := buildRenovateSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	if existing != nil {
+		out, err = mergeRenovate(existing, settings)
+	} else {
+		out, err = freshRenovate(settings)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatJSON, cfg.GeneratorExtra(g.Name()))
+}
+
+// dependencyType returns the commit type dependency update bots should use,
+// or a SkipError when the config has none.
+func dependencyType(cfg *config.Config) (string, error) {
+	if t := cfg.DependencyType(); t != "" {
+		return t, nil
+	}
+	return "", &SkipError{Reason: `no commit type for dependency updates; set "dependencies.type" or add a "deps" type`}
+}
+
+func buildRenovateSettings(cfg *config.Config) (map[string]any, error) {
+	typ, err := dependencyType(cfg)
+	if err != nil {
+		return nil, err
+	}
+	settings := map[string]any{
+		"semanticCommits":    "enabled",
+		"semanticCommitType": typ,
+	}
+	// Without a scope Renovate keeps its own default ("deps").
+	if scope := cfg.DependencyScope(); scope != "" {
+		settings["semanticCommitScope"] = scope
+	}
+	return settings, nil
+}
+
+func freshRenovate(settings map[string]any) ([]byte, error) {
+	doc := map[string]any{
+		"$schema": "https://docs.renovatebot.com/renovate-schema.json",
+	}
+	for k, v := range settings {
+		doc[k] = v
+	}
+	return marshalJSON(doc)
+}
+
+func mergeRenovate(existing []byte, settings map[string]any) ([]byte, error) {
+	doc, err := parseJSONObject(existing)
+	if err != nil {
+		return nil, fmt.Errorf("parsing existing renovate.json: %w", err)
+	}
+	doc.SetAll(settings)
+	return marshalJSONIndent(doc, jsonIndent(existing))
+}
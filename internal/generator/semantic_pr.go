@@ -0,0 +1,189 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/tylerbutler/commit-config-gen/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	Register(&SemanticPRGenerator{})
+}
+
+// semanticPRAction is the uses: prefix of the step whose inputs are generated.
+const semanticPRAction = "amannn/action-semantic-pull-request"
+
+// SemanticPRGenerator generates the types and scopes inputs of the
+// semantic-pull-request step in a GitHub Actions workflow.
+type SemanticPRGenerator struct{}
+
+func (g *SemanticPRGenerator) Name() string     { return "semantic-pr" }
+func (g *SemanticPRGenerator) FileName() string { return ".github/workflows/pr-title.yml" }
+
+// Locate returns the first workflow, in name order, with a semantic-pull-request
+// step.
+func (g *SemanticPRGenerator) Locate(dir string) (string, error) {
+	workflows := filepath.Join(".github", "workflows")
+	var names []string
+	for _, pattern := range []string{"*.yml", "*.yaml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, workflows, pattern))
+		if err != nil {
+			return "", err
+		}
+		names = append(names, matches...)
+	}
+	sort.Strings(names)
+
+	for _, path := range names {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Contains(data, []byte(semanticPRAction)) {
+			continue
+		}
+		var root yaml.Node
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return "", fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if len(semanticPRSteps(&root)) > 0 {
+			return filepath.Join(workflows, filepath.Base(path)), nil
+		}
+	}
+	return "", nil
+}
+
+func (g *SemanticPRGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
+	var types []string
+	for _, name := range cfg.TypeNames() {
+		types = append(types, name)
+		for _, alias := range cfg.Types[name].Aliases {
+			if alias.Allow {
+				types = append(types, alias.Name)
+			}
+		}
+	}
+	// Only the configured scopes: excluded scopes just hide commits from
+	// changelogs, and listing them alone would reject every other scope.
+	var scopes []string
+	if len(cfg.Scopes) > 0 {
+		scopes = slices.Sorted(maps.Keys(cfg.Scopes))
+	}
+
+	// Adding a workflow is a CI policy decision, so only an existing step is updated.
+	if existing == nil {
+		return nil, &SkipError{Reason: "no workflow uses " + semanticPRAction}
+	}
+	out, err := mergeSemanticPR(existing, types, scopes)
+	if err != nil {
+		return nil, err
+	}
+	return applyExtras(out, formatYAML, cfg.GeneratorExtra(g.Name()))
+}
+
+// mergeSemanticPR rewrites with.types and with.scopes of every matching step,
+// as the newline-separated lists the action expects. Only those entries are
+// rewritten; the rest of the workflow, blank lines included, is left alone.
+func mergeSemanticPR(existing []byte, types, scopes []string) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(existing, &root); err != nil {
+		return nil, fmt.Errorf("parsing existing workflow: %w", err)
+	}
+	steps := semanticPRSteps(&root)
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no step uses %s", semanticPRAction)
+	}
+
+	var edits []textEdit
+	for _, step := range steps {
+		with := findYAMLKey(step, "with")
+		if with == nil || with.Kind != yaml.MappingNode || with.Style&yaml.FlowStyle != 0 || findYAMLKey(with, "types") == nil {
+			// Write the whole with: mapping, keeping any other inputs.
+			if with == nil || with.Kind != yaml.MappingNode {
+				with = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			}
+			with.Style = 0
+			if err := setYAMLKey(with, "types", literalLines(types)); err != nil {
+				return nil, err
+			}
+			if len(scopes) > 0 {
+				if err := setYAMLKey(with, "scopes", literalLines(scopes)); err != nil {
+					return nil, err
+				}
+			} else {
+				deleteYAMLKey(with, "scopes")
+			}
+			edit, err := setYAMLEntry(existing, step, "with", with)
+			if err != nil {
+				return nil, err
+			}
+			edits = append(edits, edit)
+			continue
+		}
+
+		edit, err := setYAMLEntry(existing, with, "types", literalLines(types))
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit)
+		if len(scopes) > 0 {
+			if edit, err = setYAMLEntry(existing, with, "scopes", literalLines(scopes)); err != nil {
+				return nil, err
+			}
+			edits = append(edits, edit)
+		} else if edit, ok := deleteYAMLEntry(existing, with, "scopes"); ok {
+			edits = append(edits, edit)
+		}
+	}
+	return applyTextEdits(existing, edits), nil
+}
+
+// semanticPRSteps returns the steps in root whose uses: starts with
+// semanticPRAction.
+func semanticPRSteps(root *yaml.Node) []*yaml.Node {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil
+	}
+	jobs := findYAMLKey(root.Content[0], "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return nil
+	}
+	var found []*yaml.Node
+	for i := 1; i < len(jobs.Content); i += 2 {
+		if jobs.Content[i].Kind != yaml.MappingNode {
+			continue
+		}
+		steps := findYAMLKey(jobs.Content[i], "steps")
+		if steps == nil || steps.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, step := range steps.Content {
+			if step.Kind != yaml.MappingNode {
+				continue
+			}
+			if uses := findYAMLKey(step, "uses"); uses != nil && strings.HasPrefix(uses.Value, semanticPRAction) {
+				found = append(found, step)
+			}
+		}
+	}
+	return found
+}
+
+// literalLines returns lines as a "|" block scalar.
+func literalLines(lines []string) *yaml.Node {
+	return &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   "!!str",
+		Style: yaml.LiteralStyle,
+		Value: strings.Join(lines, "\n") + "\n",
+	}
+}
@@ -1,7 +1,6 @@
 package generator
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/tylerbutler/commit-config-gen/internal/config"
@@ -17,19 +16,39 @@ type SemanticReleaseGenerator struct{}
 func (g *SemanticReleaseGenerator) Name() string     { return "semantic-release" }
 func (g *SemanticReleaseGenerator) FileName() string { return ".releaserc.json" }
 
+// Locations returns the files and package.json key semantic-release reads.
+func (g *SemanticReleaseGenerator) Locations() []Location {
+	return []Location{
+		{Path: ".releaserc.json", Format: formatJSON},
+		{Path: ".releaserc"},
+		{Path: ".releaserc.yaml", Format: formatYAML},
+		{Path: ".releaserc.yml", Format: formatYAML},
+		{Path: "package.json", Format: formatJSON, Section: []string{"release"}},
+	}
+}
+
 func (g *SemanticReleaseGenerator) Generate(cfg *config.Config, existing []byte) ([]byte, error) {
+	cfg = cfg.ForGenerator(g.Name())
 	releaseRules := buildReleaseRules(cfg)
 	presetTypes := buildPresetTypes(cfg)
 
+	var out []byte
+	var err error
 	if existing != nil {
-		return mergeSemanticRelease(existing, releaseRules, presetTypes)
+		out, err = mergeSemanticRelease(existing, releaseRules, presetTypes)
+	} else {
+		out, err = freshSemanticRelease(releaseRules, presetTypes)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return freshSemanticRelease(releaseRules, presetTypes)
+	return applyExtras(out, formatJSON, cfg.GeneratorExtra(g.Name()))
 }
 
 type releaseRule struct {
-	Type    string `json:"type"`
-	Release string `json:"release,omitempty"`
+	Breaking bool   `json:"breaking,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Release  string `json:"release,omitempty"`
 }
 
 type presetType struct {
@@ -40,6 +59,9 @@ type presetType struct {
 
 func buildReleaseRules(cfg *config.Config) []releaseRule {
 	var rules []releaseRule
+	if cfg.Breaking != nil {
+		rules = append(rules, releaseRule{Breaking: true, Release: cfg.Breaking.BumpLevel()})
+	}
 	for _, name := range cfg.TypeNames() {
 		t := cfg.Types[name]
 		rule := releaseRule{Type: name}
@@ -59,6 +81,9 @@ func buildReleaseRules(cfg *config.Config) []releaseRule {
 			}
 		}
 		rules = append(rules, rule)
+		for _, alias := range t.Aliases {
+			rules = append(rules, releaseRule{Type: alias.Name, Release: rule.Release})
+		}
 	}
 	return rules
 }
@@ -74,12 +99,19 @@ func buildPresetTypes(cfg *config.Config) []presetType {
 			entry.Hidden = true
 		}
 		types = append(types, entry)
+		for _, alias := range t.Aliases {
+			aliasEntry := entry
+			aliasEntry.Type = alias.Name
+			types = append(types, aliasEntry)
+		}
 	}
 	return types
 }
 
-func freshSemanticRelease(releaseRules []releaseRule, presetTypes []presetType) ([]byte, error) {
-	plugins := []any{
+// semanticReleasePlugins returns the default plugin list, with the generated
+// rules and types.
+func semanticReleasePlugins(releaseRules []releaseRule, presetTypes []presetType) []any {
+	return []any{
 		[]any{
 			"@semantic-release/commit-analyzer",
 			map[string]any{
@@ -101,64 +133,54 @@ func freshSemanticRelease(releaseRules []releaseRule, presetTypes []presetType)
 		"@semantic-release/npm",
 		"@semantic-release/github",
 	}
+}
 
+func freshSemanticRelease(releaseRules []releaseRule, presetTypes []presetType) ([]byte, error) {
 	doc := map[string]any{
 		"branches": []string{"main"},
-		"plugins":  plugins,
+		"plugins":  semanticReleasePlugins(releaseRules, presetTypes),
 	}
 	return marshalJSON(doc)
 }
 
+// mergeSemanticRelease updates the commit-analyzer and release-notes-generator
+// plugins, or adds the default plugins when there is no plugins array. Other
+// settings are left as they were.
 func mergeSemanticRelease(existing []byte, releaseRules []releaseRule, presetTypes []presetType) ([]byte, error) {
-	var doc map[string]any
-	if err := json.Unmarshal(existing, &doc); err != nil {
+	doc, err := parseJSONObject(existing)
+	if err != nil {
 		return nil, fmt.Errorf("parsing existing .releaserc.json: %w", err)
 	}
 
-	plugins, ok := doc["plugins"].([]any)
+	plugins, ok := doc.values["plugins"].([]any)
 	if !ok {
-		// No plugins array, generate fresh structure within existing doc
-		return freshSemanticRelease(releaseRules, presetTypes)
+		doc.Set("plugins", semanticReleasePlugins(releaseRules, presetTypes))
+		return marshalJSONIndent(doc, jsonIndent(existing))
 	}
 
-	for i, plugin := range plugins {
-		arr, ok := plugin.([]any)
+	for _, plugin := range plugins {
+		arr, ok := jsonElem(plugin).([]any)
 		if !ok || len(arr) < 2 {
 			continue
 		}
-		name, ok := arr[0].(string)
+		name, ok := jsonElem(arr[0]).(string)
 		if !ok {
 			continue
 		}
-		pluginCfg, ok := arr[1].(map[string]any)
+		pluginCfg, ok := jsonElem(arr[1]).(*orderedMap)
 		if !ok {
 			continue
 		}
 
 		switch name {
 		case "@semantic-release/commit-analyzer":
-			pluginCfg["releaseRules"] = releaseRules
-			pc, ok := pluginCfg["presetConfig"].(map[string]any)
-			if !ok {
-				pc = map[string]any{}
-				pluginCfg["presetConfig"] = pc
-			}
-			pc["types"] = presetTypes
-			arr[1] = pluginCfg
-			plugins[i] = arr
+			pluginCfg.Set("releaseRules", releaseRules)
+			pluginCfg.Object("presetConfig").Set("types", presetTypes)
 
 		case "@semantic-release/release-notes-generator":
-			pc, ok := pluginCfg["presetConfig"].(map[string]any)
-			if !ok {
-				pc = map[string]any{}
-				pluginCfg["presetConfig"] = pc
-			}
-			pc["types"] = presetTypes
-			arr[1] = pluginCfg
-			plugins[i] = arr
+			pluginCfg.Object("presetConfig").Set("types", presetTypes)
 		}
 	}
 
-	doc["plugins"] = plugins
-	return marshalJSON(doc)
+	return marshalJSONIndent(doc, jsonIndent(existing))
 }
@@ -0,0 +1,396 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// The helpers in this file edit TOML documents as text, so comments, key
+// order and string styles outside the edited values survive a merge.
+
+// tomlSection is the root table or a [table] / [[array-table]] block.
+type tomlSection struct {
+	key   []string // nil for the root table
+	array bool
+	start int // start of the header line
+	body  int // end of the header line
+	end   int // end of the header line or of the last key/value line
+	keys  []tomlKeyValue
+}
+
+type tomlKeyValue struct {
+	key        []string
+	str        string // decoded value, when it is a string
+	valueStart int
+	valueEnd   int
+	end        int // end of the line holding the value
+}
+
+// parseTOMLSections splits data into its tables and their key/values.
+func parseTOMLSections(data []byte) ([]*tomlSection, error) {
+	root := &tomlSection{}
+	sections := []*tomlSection{root}
+	current := root
+
+	p := unstable.Parser{}
+	p.Reset(data)
+	for p.NextExpression() {
+		expr := p.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			key, keyStart, keyEnd := tomlKey(expr)
+			current = &tomlSection{
+				key:   key,
+				array: expr.Kind == unstable.ArrayTable,
+				start: lineStart(data, keyStart),
+				body:  lineEnd(data, keyEnd),
+				end:   lineEnd(data, keyEnd),
+			}
+			sections = append(sections, current)
+		case unstable.KeyValue:
+			key, _, keyEnd := tomlKey(expr)
+			eq := bytes.IndexByte(data[keyEnd:], '=')
+			if eq < 0 {
+				return nil, fmt.Errorf("malformed key/value %q", strings.Join(key, "."))
+			}
+			start := skipTOMLSpace(data, keyEnd+eq+1)
+			end := scanTOMLValue(data, start)
+			kv := tomlKeyValue{key: key, valueStart: start, valueEnd: end, end: lineEnd(data, end)}
+			if v := expr.Value(); v.Kind == unstable.String {
+				kv.str = string(v.Data)
+			}
+			current.keys = append(current.keys, kv)
+			current.end = kv.end
+		}
+	}
+	if err := p.Error(); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// checkTOMLTable reports key/values that define part of table from outside
+// its own [table] blocks, such as dotted keys (git.commit_parsers = ...) or an
+// inline table (git = { ... }). Edits made by table header can't see them.
+func checkTOMLTable(sections []*tomlSection, table []string, array bool) error {
+	for _, s := range sections {
+		if len(s.key) >= len(table) && slices.Equal(s.key[:len(table)], table) {
+			continue
+		}
+		for _, kv := range s.keys {
+			full := slices.Concat(s.key, kv.key)
+			n := min(len(full), len(table))
+			if slices.Equal(full[:n], table[:n]) {
+				return fmt.Errorf("%s must be written as %s tables", strings.Join(full, "."), tomlHeader(table, array))
+			}
+		}
+	}
+	return nil
+}
+
+// parseTOMLTable is parseTOMLSections for an edit of table.
+func parseTOMLTable(data []byte, table []string, array bool) ([]*tomlSection, error) {
+	sections, err := parseTOMLSections(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTOMLTable(sections, table, array); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// tomlKey returns the dotted key of a table or key/value expression and the
+// byte range it spans.
+func tomlKey(expr *unstable.Node) (key []string, start, end int) {
+	it := expr.Key()
+	start = -1
+	for it.Next() {
+		n := it.Node()
+		key = append(key, string(n.Data))
+		if start < 0 {
+			start = int(n.Raw.Offset)
+		}
+		end = int(n.Raw.Offset + n.Raw.Length)
+	}
+	return key, start, end
+}
+
+// scanTOMLValue returns the end of the value starting at i.
+func scanTOMLValue(data []byte, i int) int {
+	depth := 0
+	for i < len(data) {
+		switch c := data[i]; {
+		case c == '"' || c == '\'':
+			i = skipTOMLString(data, i)
+			if depth == 0 {
+				return i
+			}
+			continue
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case c == '#' && depth > 0:
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			continue
+		case (c == '#' || c == '\n' || c == '\r') && depth == 0:
+			return len(bytes.TrimRight(data[:i], " \t"))
+		}
+		i++
+	}
+	return len(bytes.TrimRight(data, " \t\r\n"))
+}
+
+// skipTOMLString returns the offset just past the string starting at i.
+func skipTOMLString(data []byte, i int) int {
+	quote := data[i]
+	delim := []byte{quote}
+	if bytes.HasPrefix(data[i:], []byte{quote, quote, quote}) {
+		delim = []byte{quote, quote, quote}
+	}
+	i += len(delim)
+	for i < len(data) {
+		if quote == '"' && data[i] == '\\' {
+			i = min(i+2, len(data)) // a trailing backslash ends the data
+			continue
+		}
+		if bytes.HasPrefix(data[i:], delim) {
+			i += len(delim)
+			// up to two extra quotes may close a multi-line string
+			for len(delim) == 3 && i < len(data) && data[i] == quote {
+				i++
+			}
+			return i
+		}
+		i++
+	}
+	return i
+}
+
+func skipTOMLSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+func lineStart(data []byte, i int) int {
+	return bytes.LastIndexByte(data[:i], '\n') + 1
+}
+
+// lineEnd returns the offset after the newline ending the line holding i.
+func lineEnd(data []byte, i int) int {
+	if n := bytes.IndexByte(data[i:], '\n'); n >= 0 {
+		return i + n + 1
+	}
+	return len(data)
+}
+
+// setTOMLValue sets key in the [table] block (the root table when table is
+// empty) to value, which must already be formatted as TOML. A missing key is
+// added after the table's last key, and a missing table at the end of data.
+func setTOMLValue(data []byte, table []string, key, value string) ([]byte, error) {
+	sections, err := parseTOMLTable(data, table, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sections {
+		if !s.array && slices.Equal(s.key, table) {
+			return setTOMLSectionValue(data, s, key, value), nil
+		}
+	}
+	return appendTOMLSection(data, table, false, [][2]string{{key, value}}), nil
+}
+
+// setTOMLArrayTableValue sets key in the [[table]] block whose match key
+// equals matchValue, appending a new block when there is none.
+func setTOMLArrayTableValue(data []byte, table []string, match, matchValue, key, value string) ([]byte, error) {
+	sections, err := parseTOMLTable(data, table, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sections {
+		if !s.array || !slices.Equal(s.key, table) {
+			continue
+		}
+		for _, kv := range s.keys {
+			if slices.Equal(kv.key, []string{match}) && kv.str == matchValue {
+				return setTOMLSectionValue(data, s, key, value), nil
+			}
+		}
+	}
+	return appendTOMLSection(data, table, true, [][2]string{{match, tomlString(matchValue)}, {key, value}}), nil
+}
+
+// deleteTOMLArrayTables removes every [[table]] block, along with the blank
+// lines after it.
+func deleteTOMLArrayTables(data []byte, table []string) ([]byte, error) {
+	return deleteTOMLSections(data, func(s *tomlSection) bool {
+		return s.array && slices.Equal(s.key, table)
+	})
+}
+
+// deleteTOMLTables removes the [table] block and every table below it.
+func deleteTOMLTables(data []byte, table []string) ([]byte, error) {
+	return deleteTOMLSections(data, func(s *tomlSection) bool {
+		return len(s.key) >= len(table) && slices.Equal(s.key[:len(table)], table)
+	})
+}
+
+func deleteTOMLSections(data []byte, match func(*tomlSection) bool) ([]byte, error) {
+	sections, err := parseTOMLSections(data)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(sections) - 1; i >= 0; i-- {
+		s := sections[i]
+		if s.key == nil || !match(s) {
+			continue
+		}
+		start, end := s.start, s.end
+		for end < len(data) && (data[end] == '\n' || data[end] == '\r') {
+			end++
+		}
+		if end == len(data) {
+			// don't leave blank lines at the end of the file
+			for start > 1 && data[start-1] == '\n' && data[start-2] == '\n' {
+				start--
+			}
+		}
+		data = slices.Concat(data[:start], data[end:])
+	}
+	return data, nil
+}
+
+func setTOMLSectionValue(data []byte, s *tomlSection, key, value string) []byte {
+	for _, kv := range s.keys {
+		if slices.Equal(kv.key, []string{key}) {
+			return slices.Concat(data[:kv.valueStart], []byte(value), data[kv.valueEnd:])
+		}
+	}
+	line := []byte(tomlKeyName(key) + " = " + value + "\n")
+	at := s.end
+	if at > 0 && data[at-1] != '\n' {
+		line = append([]byte("\n"), line...)
+	}
+	return slices.Concat(data[:at], line, data[at:])
+}
+
+func appendTOMLSection(data []byte, table []string, array bool, values [][2]string) []byte {
+	var sb strings.Builder
+	sb.Write(data)
+	if len(data) > 0 {
+		if !bytes.HasSuffix(data, []byte("\n")) {
+			sb.WriteString("\n")
+		}
+		if !bytes.HasSuffix(data, []byte("\n\n")) {
+			sb.WriteString("\n")
+		}
+	}
+	if len(table) > 0 {
+		sb.WriteString(tomlHeader(table, array) + "\n")
+	}
+	for _, kv := range values {
+		fmt.Fprintf(&sb, "%s = %s\n", tomlKeyName(kv[0]), kv[1])
+	}
+	return []byte(sb.String())
+}
+
+// tomlHeader formats the header line of a [table] or [[array-table]].
+func tomlHeader(table []string, array bool) string {
+	names := make([]string, len(table))
+	for i, name := range table {
+		names[i] = tomlKeyName(name)
+	}
+	if array {
+		return "[[" + strings.Join(names, ".") + "]]"
+	}
+	return "[" + strings.Join(names, ".") + "]"
+}
+
+// tomlKeyName quotes key unless it is a bare key.
+func tomlKeyName(key string) string {
+	if key == "" {
+		return `""`
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return tomlString(key)
+		}
+	}
+	return key
+}
+
+// tomlString formats s as a literal string when it can be one, so regexes
+// don't need escaping, and as a basic string otherwise.
+func tomlString(s string) string {
+	literal := !strings.ContainsRune(s, '\'')
+	for _, r := range s {
+		if r < 0x20 && r != '\t' || r == 0x7f {
+			literal = false
+		}
+	}
+	if literal {
+		return "'" + s + "'"
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// tomlParserArray formats commit parsers as an array of inline tables, one
+// per line.
+func tomlParserArray(parsers []commitParser) string {
+	var sb strings.Builder
+	sb.WriteString("[\n")
+	for _, p := range parsers {
+		var fields []string
+		if p.Message != "" {
+			fields = append(fields, "message = "+tomlString(p.Message))
+		}
+		if p.Footer != "" {
+			fields = append(fields, "footer = "+tomlString(p.Footer))
+		}
+		if p.Group != "" {
+			fields = append(fields, "group = "+tomlString(p.Group))
+		}
+		if p.Skip {
+			fields = append(fields, "skip = true")
+		}
+		fmt.Fprintf(&sb, "    { %s },\n", strings.Join(fields, ", "))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
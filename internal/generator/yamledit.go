@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The helpers in this file edit YAML documents as text. yaml.v3 keeps
+// comments when re-encoding a node tree but drops blank lines, so merges
+// rewrite only the entries they change and splice them into the original.
+
+// textEdit replaces data[start:end] with text.
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+// applyTextEdits applies non-overlapping edits to data. Insertions at the
+// same offset keep the order they were made in.
+func applyTextEdits(data []byte, edits []textEdit) []byte {
+	edits = slices.Clone(edits)
+	slices.SortStableFunc(edits, func(a, b textEdit) int { return a.start - b.start })
+	for i := len(edits) - 1; i >= 0; i-- {
+		e := edits[i]
+		data = slices.Concat(data[:e.start], []byte(e.text), data[e.end:])
+	}
+	return data
+}
+
+// yamlOffset returns the byte offset of a node's line and column.
+func yamlOffset(data []byte, line, column int) int {
+	offset := 0
+	for ; line > 1; line-- {
+		offset += bytes.IndexByte(data[offset:], '\n') + 1
+	}
+	return offset + column - 1
+}
+
+// yamlEntryEnd returns the end of the entry whose key starts at offset: the
+// end of the last following line indented deeper than the key. Trailing
+// blank lines and comments at the key's indentation aren't part of it.
+func yamlEntryEnd(data []byte, offset int) int {
+	indent := offset - lineStart(data, offset)
+	end := lineEnd(data, offset)
+	if end > 0 && data[end-1] == '\n' {
+		end--
+	}
+	for next := lineEnd(data, offset); next < len(data); next = lineEnd(data, next) {
+		line := data[next:lineEnd(data, next)]
+		trimmed := bytes.TrimLeft(line, " ")
+		if len(bytes.TrimSpace(trimmed)) == 0 {
+			continue
+		}
+		if len(line)-len(trimmed) <= indent {
+			break
+		}
+		end = next + len(bytes.TrimRight(line, "\r\n"))
+	}
+	return end
+}
+
+// renderYAMLEntry encodes key: value, with every line after the first
+// indented by indent spaces.
+func renderYAMLEntry(key string, value *yaml.Node, indent int) (string, error) {
+	entry := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value,
+	}}
+	data, err := encodeYAML(entry)
+	if err != nil {
+		return "", err
+	}
+	return reindent(data, strings.Repeat(" ", indent)), nil
+}
+
+// setYAMLEntry returns the edit that sets key to value in the block mapping m,
+// replacing the key's entry or adding one after m's last entry.
+func setYAMLEntry(data []byte, m *yaml.Node, key string, value *yaml.Node) (textEdit, error) {
+	if m.Kind != yaml.MappingNode || m.Style&yaml.FlowStyle != 0 || len(m.Content) == 0 {
+		return textEdit{}, fmt.Errorf("expected a block mapping at line %d", m.Line)
+	}
+	first := m.Content[0]
+	indent := yamlOffset(data, first.Line, first.Column) - yamlOffset(data, first.Line, 1)
+	text, err := renderYAMLEntry(key, value, indent)
+	if err != nil {
+		return textEdit{}, err
+	}
+	for i := 0; i < len(m.Content)-1; i += 2 {
+		if k := m.Content[i]; k.Value == key {
+			start := yamlOffset(data, k.Line, k.Column)
+			return textEdit{start, yamlEntryEnd(data, start), text}, nil
+		}
+	}
+	last := m.Content[len(m.Content)-2]
+	end := yamlEntryEnd(data, yamlOffset(data, last.Line, last.Column))
+	return textEdit{end, end, "\n" + strings.Repeat(" ", indent) + text}, nil
+}
+
+// deleteYAMLEntry returns the edit that removes key's entry from the block
+// mapping m, and whether there is one.
+func deleteYAMLEntry(data []byte, m *yaml.Node, key string) (textEdit, bool) {
+	for i := 0; i < len(m.Content)-1; i += 2 {
+		if k := m.Content[i]; k.Value == key {
+			start := yamlOffset(data, k.Line, k.Column)
+			end := yamlEntryEnd(data, start)
+			// take the whole line unless the key shares it, as in "- key: ..."
+			if ls := lineStart(data, start); len(bytes.TrimLeft(data[ls:start], " ")) == 0 {
+				start, end = ls, lineEnd(data, end)
+			}
+			return textEdit{start, end, ""}, true
+		}
+	}
+	return textEdit{}, false
+}
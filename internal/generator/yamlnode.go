@@ -0,0 +1,68 @@
+package generator
+
+import "gopkg.in/yaml.v3"
+
+// setYAMLKey replaces the value of key in mapping, appending the key if it is
+// missing. Other keys and their comments are left alone.
+func setYAMLKey(mapping *yaml.Node, key string, value any) error {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	valNode := &doc
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		valNode = doc.Content[0]
+	}
+
+	for i := 0; i < len(mapping.Content)-1; i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = valNode
+			return nil
+		}
+	}
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: key, Tag: "!!str"}
+	mapping.Content = append(mapping.Content, keyNode, valNode)
+	return nil
+}
+
+// findYAMLKey returns the value of key in mapping, or nil if it is missing.
+func findYAMLKey(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(mapping.Content)-1; i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// ensureYAMLMapping returns the mapping stored under key, creating it (or
+// replacing a non-mapping value) when needed.
+func ensureYAMLMapping(mapping *yaml.Node, key string) *yaml.Node {
+	if child := findYAMLKey(mapping, key); child != nil && child.Kind == yaml.MappingNode {
+		return child
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i := 0; i < len(mapping.Content)-1; i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = child
+			return child
+		}
+	}
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: key, Tag: "!!str"}
+	mapping.Content = append(mapping.Content, keyNode, child)
+	return child
+}
+
+// deleteYAMLKey removes key and its value from mapping, if present.
+func deleteYAMLKey(mapping *yaml.Node, key string) {
+	for i := 0; i < len(mapping.Content)-1; i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
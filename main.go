@@ -2,6 +2,8 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -20,8 +22,7 @@ func main() {
 			&cli.StringFlag{
 				Name:    "config",
 				Aliases: []string{"c"},
-				Value:   "commit-types.json",
-				Usage:   "path to commit-types.json",
+				Usage:   "path to commit-types file (.json, .yaml/.yml or .toml; default: search up to the git root)",
 			},
 		},
 		Commands: []*cli.Command{
@@ -36,8 +37,7 @@ func main() {
 					&cli.StringFlag{
 						Name:    "output",
 						Aliases: []string{"o"},
-						Value:   ".",
-						Usage:   "output directory for generated files",
+						Usage:   "output directory for generated files (default: the discovered config file's directory, or the current directory with --config)",
 					},
 					&cli.StringSliceFlag{
 						Name:    "generators",
@@ -54,8 +54,7 @@ func main() {
 					&cli.StringFlag{
 						Name:    "dir",
 						Aliases: []string{"d"},
-						Value:   ".",
-						Usage:   "directory containing config files to check",
+						Usage:   "directory containing config files to check (default: the discovered config file's directory, or the current directory with --config)",
 					},
 					&cli.StringSliceFlag{
 						Name:    "generators",
@@ -65,6 +64,57 @@ func main() {
 				},
 				Action: runCheck,
 			},
+			{
+				Name:   "validate",
+				Usage:  "Validate commit-types.json and report any problems",
+				Action: runValidate,
+			},
+			{
+				Name:  "init",
+				Usage: "Write a starter commit-types.json from a built-in preset",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "preset",
+						Aliases: []string{"p"},
+						Value:   "keepachangelog",
+						Usage:   "preset to start from (" + strings.Join(config.PresetNames(), ", ") + ")",
+					},
+					&cli.BoolFlag{
+						Name:  "extends",
+						Usage: "reference the preset via extends instead of copying its types",
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "overwrite an existing config file",
+					},
+				},
+				Action: runInit,
+			},
+			{
+				Name:  "config",
+				Usage: "Inspect commit-types.json",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "show",
+						Usage: "Print the config file",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "resolved",
+								Usage: "print the flattened result after applying extends",
+							},
+						},
+						Action: runConfigShow,
+					},
+				},
+			},
+			{
+				Name:  "schema",
+				Usage: "Print the JSON Schema for commit-types.json",
+				Action: func(c *cli.Context) error {
+					_, err := os.Stdout.Write(config.Schema())
+					return err
+				},
+			},
 			{
 				Name:  "list",
 				Usage: "List available generators",
@@ -99,14 +149,63 @@ func selectedGenerators(names []string) ([]generator.Generator, error) {
 	return gens, nil
 }
 
+// resolveConfigPath returns the --config value, or the config discovered from
+// the working directory when the flag is not set.
+func resolveConfigPath(c *cli.Context) (string, error) {
+	if path := c.String("config"); path != "" {
+		return path, nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	path, err := config.Discover(wd)
+	if err != nil {
+		return "", err
+	}
+	if rel, err := filepath.Rel(wd, path); err == nil {
+		path = rel
+	}
+	return path, nil
+}
+
+// targetDir returns the directory named by flag, or the default: the
+// directory of a discovered config, or the current directory when the config
+// was given with --config.
+func targetDir(c *cli.Context, flag, configPath string) string {
+	if dir := c.String(flag); dir != "" {
+		return dir
+	}
+	if c.String("config") != "" {
+		return "."
+	}
+	return filepath.Dir(configPath)
+}
+
+// loadConfig loads the config and refuses to continue if it is semantically invalid.
+func loadConfig(path string) (*config.Config, error) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	problems := append(cfg.Validate(), cfg.ValidateGeneratorNames(generator.Names())...)
+	if len(problems) > 0 {
+		return nil, &config.ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
 func runGenerate(c *cli.Context) error {
-	configPath := c.String("config")
-	outputDir := c.String("output")
+	configPath, err := resolveConfigPath(c)
+	if err != nil {
+		return err
+	}
+	outputDir := targetDir(c, "output", configPath)
 	dryRun := c.Bool("dry-run")
 
-	cfg, err := config.Load(configPath)
+	cfg, err := loadConfig(configPath)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
 
 	gens, err := selectedGenerators(c.StringSlice("generators"))
@@ -115,41 +214,54 @@ func runGenerate(c *cli.Context) error {
 	}
 
 	for _, gen := range gens {
-		filePath := filepath.Join(outputDir, gen.FileName())
+		target, err := generator.Resolve(gen, outputDir)
+		if err != nil {
+			return fmt.Errorf("failed to locate %s: %w", gen.FileName(), err)
+		}
 
 		// Read existing file for merge
 		var existing []byte
-		if data, err := os.ReadFile(filePath); err == nil {
+		if data, err := os.ReadFile(target.Path); err == nil {
 			existing = data
 		}
 
-		output, err := gen.Generate(cfg, existing)
-		if err != nil {
+		output, err := target.Generate(gen, cfg, existing)
+		var skip *generator.SkipError
+		if errors.As(err, &skip) {
+			fmt.Printf("Skipped %s: %s\n", target.Path, skip.Reason)
+			continue
+		} else if err != nil {
 			return fmt.Errorf("failed to generate %s: %w", gen.FileName(), err)
 		}
 
 		if dryRun {
-			fmt.Printf("=== %s ===\n", gen.FileName())
+			fmt.Printf("=== %s ===\n", target.Path)
 			fmt.Println(string(output))
 			continue
 		}
 
-		if err := os.WriteFile(filePath, output, 0o644); err != nil {
+		if err := os.MkdirAll(filepath.Dir(target.Path), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", gen.FileName(), err)
+		}
+		if err := os.WriteFile(target.Path, output, 0o644); err != nil {
 			return fmt.Errorf("failed to write %s: %w", gen.FileName(), err)
 		}
-		fmt.Printf("Wrote %s\n", filePath)
+		fmt.Printf("Wrote %s\n", target.Path)
 	}
 
 	return nil
 }
 
 func runCheck(c *cli.Context) error {
-	configPath := c.String("config")
-	dir := c.String("dir")
+	configPath, err := resolveConfigPath(c)
+	if err != nil {
+		return err
+	}
+	dir := targetDir(c, "dir", configPath)
 
-	cfg, err := config.Load(configPath)
+	cfg, err := loadConfig(configPath)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
 
 	gens, err := selectedGenerators(c.StringSlice("generators"))
@@ -160,9 +272,12 @@ func runCheck(c *cli.Context) error {
 	var errs []string
 
 	for _, gen := range gens {
-		filePath := filepath.Join(dir, gen.FileName())
+		target, err := generator.Resolve(gen, dir)
+		if err != nil {
+			return fmt.Errorf("failed to locate %s: %w", gen.FileName(), err)
+		}
 
-		actual, err := os.ReadFile(filePath)
+		actual, err := os.ReadFile(target.Path)
 		if err != nil {
 			if os.IsNotExist(err) {
 				continue // skip files that don't exist
@@ -170,13 +285,16 @@ func runCheck(c *cli.Context) error {
 			return fmt.Errorf("failed to read %s: %w", gen.FileName(), err)
 		}
 
-		expected, err := gen.Generate(cfg, actual)
-		if err != nil {
+		expected, err := target.Generate(gen, cfg, actual)
+		var skip *generator.SkipError
+		if errors.As(err, &skip) {
+			continue // nothing to compare against
+		} else if err != nil {
 			return fmt.Errorf("failed to generate %s: %w", gen.FileName(), err)
 		}
 
 		if !bytes.Equal(bytes.TrimSpace(expected), bytes.TrimSpace(actual)) {
-			errs = append(errs, fmt.Sprintf("%s is out of sync with commit-types.json", gen.FileName()))
+			errs = append(errs, fmt.Sprintf("%s is out of sync with commit-types.json", target.Path))
 		}
 	}
 
@@ -192,3 +310,88 @@ func runCheck(c *cli.Context) error {
 	fmt.Println("All configs are in sync with commit-types.json")
 	return nil
 }
+
+func runValidate(c *cli.Context) error {
+	configPath, err := resolveConfigPath(c)
+	if err != nil {
+		return err
+	}
+
+	var problems []config.Problem
+	problemFile := configPath
+	cfg, err := config.Load(configPath)
+	var verr *config.ValidationError
+	switch {
+	case errors.As(err, &verr):
+		problems = verr.Problems
+		if verr.File != "" {
+			problemFile = verr.File
+		}
+	case err != nil:
+		return fmt.Errorf("failed to load config: %w", err)
+	default:
+		problems = append(cfg.Validate(), cfg.ValidateGeneratorNames(generator.Names())...)
+	}
+
+	if len(problems) > 0 {
+		fmt.Printf("%s has %d problem(s):\n", problemFile, len(problems))
+		for _, p := range problems {
+			fmt.Printf("  - %s\n", p)
+		}
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s is valid\n", configPath)
+	return nil
+}
+
+func runInit(c *cli.Context) error {
+	configPath := c.String("config")
+	if configPath == "" {
+		configPath = "commit-types.json"
+	}
+
+	if !c.Bool("force") {
+		if _, err := os.Stat(configPath); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", configPath)
+		}
+	}
+
+	data, err := config.Starter(c.String("preset"), c.Bool("extends"), configPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(configPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", configPath, err)
+	}
+	fmt.Printf("Wrote %s\n", configPath)
+	return nil
+}
+
+func runConfigShow(c *cli.Context) error {
+	configPath, err := resolveConfigPath(c)
+	if err != nil {
+		return err
+	}
+
+	if !c.Bool("resolved") {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to read config: %w", err)
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}